@@ -2,20 +2,20 @@ package ibclient
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
-
-	"golang.org/x/net/publicsuffix"
 )
 
 // HostConfig defines the InfoBlox host
@@ -25,40 +25,57 @@ type HostConfig struct {
 	Port     string
 	Username string
 	Password string
+	// Credentials, if set, is asked for the credentials whenever they are
+	// sent instead of using Username and Password
+	Credentials CredentialProvider
+	// AuthMode selects between Basic Auth on every request, the default,
+	// and reusing the ibapauth session cookie
+	AuthMode AuthMode
 }
 
 // TransportConfig contains HTTP transport configuration
 type TransportConfig struct {
 	SslVerify           bool
 	certPool            *x509.CertPool
+	minVersion          uint16
+	cipherSuites        []uint16
+	serverName          string
+	spkiPins            [][]byte
 	HTTPRequestTimeout  time.Duration // in seconds
 	HTTPPoolConnections int
+	// ClientCertificates are presented to the Grid for mutual TLS
+	// authentication
+	ClientCertificates []tls.Certificate
+	// RetryPolicy decides which failed requests are sent again, nil
+	// disables retries
+	RetryPolicy RetryPolicy
+	// ProxySearch controls when GET requests are sent to the Grid Master
+	ProxySearch ProxySearchMode
+
+	// err is the configuration error reported by the requests
+	err error
 }
 
-// NewTransportConfig a newly created TransportConfig
+// NewTransportConfig a newly created TransportConfig. sslVerify is "true",
+// "false" or the path of a CA certificate file. If the file cannot be
+// loaded, the requests sent with the TransportConfig fail with that error;
+// use NewTransportConfigWithTLS to get it immediately.
 func NewTransportConfig(sslVerify string, httpRequestTimeout int, httpPoolConnections int) (cfg TransportConfig) {
-	switch {
-	case "false" == strings.ToLower(sslVerify):
-		cfg.SslVerify = false
-	case "true" == strings.ToLower(sslVerify):
-		cfg.SslVerify = true
+	var tlsCfg TLSConfig
+	switch strings.ToLower(sslVerify) {
+	case "false":
+		tlsCfg.InsecureSkipVerify = true
+	case "true":
 	default:
-		caPool := x509.NewCertPool()
-		cert, err := ioutil.ReadFile(sslVerify)
-		if err != nil {
-			// log.Printf("Cannot load certificate file '%s'", sslVerify)
-			return
-		}
-		if !caPool.AppendCertsFromPEM(cert) {
-			// err = fmt.Errorf("Cannot append certificate from file '%s'", sslVerify)
-			return
-		}
-		cfg.certPool = caPool
-		cfg.SslVerify = true
+		tlsCfg.CAFiles = []string{sslVerify}
 	}
 
-	cfg.HTTPPoolConnections = httpPoolConnections
-	cfg.HTTPRequestTimeout = time.Duration(httpRequestTimeout)
+	cfg, err := NewTransportConfigWithTLS(tlsCfg, httpRequestTimeout, httpPoolConnections)
+	if err != nil {
+		// fail closed, never fall back to unverified connections
+		cfg.SslVerify = true
+		cfg.err = err
+	}
 	return
 }
 
@@ -66,9 +83,10 @@ func NewTransportConfig(sslVerify string, httpRequestTimeout int, httpPoolConnec
 // for creating HTTP requests
 type HTTPRequestBuilder interface {
 	Init(HostConfig)
-	BuildUrl(r RequestType, objType string, ref string, returnFields []string, queryParams QueryParams) (urlStr string)
+	BuildURL(r RequestType, objType string, ref string, returnFields []string, queryParams QueryParams) (urlStr string)
 	BuildBody(r RequestType, obj IBObject) (jsonStr []byte)
 	BuildRequest(r RequestType, obj IBObject, ref string, queryParams QueryParams) (req *http.Request, err error)
+	BuildRequestWithContext(ctx context.Context, r RequestType, obj IBObject, ref string, queryParams QueryParams) (req *http.Request, err error)
 }
 
 // HTTPRequestor is the interface implemented by clients to send HTTP requests
@@ -76,6 +94,7 @@ type HTTPRequestBuilder interface {
 type HTTPRequestor interface {
 	Init(TransportConfig)
 	SendRequest(*http.Request) ([]byte, error)
+	SendRequestWithContext(context.Context, *http.Request) ([]byte, error)
 }
 
 // WapiRequestBuilder TBD
@@ -85,7 +104,11 @@ type WapiRequestBuilder struct {
 
 // WapiHTTPRequestor TBD
 type WapiHTTPRequestor struct {
-	client http.Client
+	client      http.Client
+	jar         *sessionJar
+	retryPolicy RetryPolicy
+	// initErr is returned by every request if Init failed
+	initErr error
 }
 
 // IBConnector TBD
@@ -94,6 +117,12 @@ type IBConnector interface {
 	GetObject(obj IBObject, ref string, res interface{}) error
 	DeleteObject(ref string) (refRes string, err error)
 	UpdateObject(obj IBObject, ref string) (refRes string, err error)
+	CreateObjectWithContext(ctx context.Context, obj IBObject) (ref string, err error)
+	GetObjectWithContext(ctx context.Context, obj IBObject, ref string, res interface{}) error
+	DeleteObjectWithContext(ctx context.Context, ref string) (refRes string, err error)
+	UpdateObjectWithContext(ctx context.Context, obj IBObject, ref string) (refRes string, err error)
+	GetObjectPage(obj IBObject, pageID string, maxResults int, res interface{}) (nextPageID string, err error)
+	GetObjectPageWithContext(ctx context.Context, obj IBObject, pageID string, maxResults int, res interface{}) (nextPageID string, err error)
 }
 
 // Connector TBD
@@ -102,6 +131,13 @@ type Connector struct {
 	TransportConfig TransportConfig
 	RequestBuilder  HTTPRequestBuilder
 	Requestor       HTTPRequestor
+	// Logger receives request and error details, nothing is logged if nil
+	Logger Logger
+	// Instrumentation is called around every WAPI request, e.g. to collect
+	// metrics with a MetricsCollector or to trace requests
+	Instrumentation Instrumentation
+
+	session int32 // 1 while logged in with AuthSession
 }
 
 // RequestType wraps conversion between CRUD and HTTP methods
@@ -133,52 +169,121 @@ func (r RequestType) toMethod() string {
 	return ""
 }
 
-func getHTTPResponseError(resp *http.Response) error {
+func getHTTPResponseError(req *http.Request, resp *http.Response) error {
 	defer resp.Body.Close()
 	content, _ := ioutil.ReadAll(resp.Body)
-	msg := fmt.Sprintf("WAPI request error: %d('%s')\nContents:\n%s\n", resp.StatusCode, resp.Status, content)
-	// log.Print(msg)
-	return errors.New(msg)
+	return NewWapiError(req.Method, objectTypeFromURL(req.URL), resp.StatusCode, content)
 }
 
 // Init sets up a connector client
 func (whr *WapiHTTPRequestor) Init(cfg TransportConfig) {
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: !cfg.SslVerify,
-			RootCAs:       cfg.certPool,
-			Renegotiation: tls.RenegotiateOnceAsClient},
+		TLSClientConfig:     cfg.tlsClientConfig(),
 		MaxIdleConnsPerHost: cfg.HTTPPoolConnections,
 	}
 
-	// All users of cookiejar should import "golang.org/x/net/publicsuffix"
-	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
-	if err != nil {
-		panic(err) // XXX Fix this!
+	whr.initErr = cfg.err
+	jar, err := newSessionJar()
+	if err != nil && whr.initErr == nil {
+		whr.initErr = fmt.Errorf("cannot create cookie jar: %w", err)
 	}
 
+	whr.jar = jar
 	whr.client = http.Client{Jar: jar, Transport: tr, Timeout: cfg.HTTPRequestTimeout * time.Second}
+	whr.retryPolicy = cfg.RetryPolicy
 }
 
 // SendRequest makes an HTTP request and returns the response body
 func (whr *WapiHTTPRequestor) SendRequest(req *http.Request) (res []byte, err error) {
+	return whr.SendRequestWithContext(req.Context(), req)
+}
+
+// SendRequestWithContext makes an HTTP request bound to ctx and returns the
+// response body. Failed requests are retried as allowed by the RetryPolicy
+// of the TransportConfig.
+func (whr *WapiHTTPRequestor) SendRequestWithContext(ctx context.Context, req *http.Request) (res []byte, err error) {
+	if whr.initErr != nil {
+		return nil, whr.initErr
+	}
+	req = req.WithContext(ctx)
+	stats := requestStatsFrom(ctx)
+
 	var resp *http.Response
-	resp, err = whr.client.Do(req)
-	if err != nil {
-		return
-	} else if !(resp.StatusCode == http.StatusOK ||
-		(resp.StatusCode == http.StatusCreated &&
-			req.Method == RequestType(CREATE).toMethod())) {
-		err := getHTTPResponseError(resp)
-		return nil, err
+	for attempt := 1; ; attempt++ {
+		resp, err = whr.client.Do(req)
+		if resp != nil {
+			stats.statusCode = resp.StatusCode
+		}
+		if err == nil && isSuccessStatus(req, resp) {
+			break
+		}
+
+		retry := false
+		var delay time.Duration
+		if whr.retryPolicy != nil && ctx.Err() == nil {
+			retry, delay = whr.retryPolicy.ShouldRetry(attempt, req, resp, err)
+		}
+		if retry {
+			retry = rewindBody(req)
+		}
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			return nil, getHTTPResponseError(req, resp)
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err = sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+		stats.retries++
 	}
 	defer resp.Body.Close()
 	res, err = ioutil.ReadAll(resp.Body)
+	return
+}
+
+func isSuccessStatus(req *http.Request, resp *http.Response) bool {
+	return resp.StatusCode == http.StatusOK ||
+		(resp.StatusCode == http.StatusCreated &&
+			req.Method == RequestType(CREATE).toMethod())
+}
+
+// rewindBody resets the body of req so it can be sent again, reporting
+// whether that was possible
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
 	if err != nil {
-		// log.Printf("Http Reponse ioutil.ReadAll() Error: '%s'", err)
-		return
+		return false
 	}
+	req.Body = body
+	return true
+}
 
-	return
+// sleepContext waits for d to elapse or ctx to be done, whichever happens
+// first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
 
 // Init copies the provided HostConfig into the WapiRequestBuilder
@@ -198,14 +303,32 @@ func (wrb *WapiRequestBuilder) BuildURL(t RequestType, objType string, ref strin
 	qry := ""
 	vals := url.Values{}
 	if t == GET {
-		if len(returnFields) > 0 {
-			vals.Set("_return_fields", strings.Join(returnFields, ","))
+		// the query of the first page is remembered by WAPI, following pages
+		// are only selected by their id
+		if queryParams.pageID != "" {
+			vals.Set("_page_id", queryParams.pageID)
+		} else {
+			if len(returnFields) > 0 {
+				vals.Set("_return_fields", strings.Join(returnFields, ","))
+			}
+			for k, v := range queryParams.query.Values() {
+				vals[k] = append(vals[k], v...)
+			}
+			if queryParams.paging {
+				vals.Set("_paging", "1")
+				vals.Set("_return_as_object", "1")
+				if queryParams.maxResults > 0 {
+					vals.Set("_max_results", strconv.Itoa(queryParams.maxResults))
+				}
+			}
 		}
 		// TODO need to get this from individual objects in future
 		if queryParams.forceProxy {
 			vals.Set("_proxy_search", "GM")
 		}
 		qry = vals.Encode()
+	} else if t == CREATE && queryParams.function != "" {
+		qry = url.Values{"_function": {queryParams.function}}.Encode()
 	}
 
 	u := url.URL{
@@ -226,7 +349,6 @@ func (wrb *WapiRequestBuilder) BuildBody(t RequestType, obj IBObject) []byte {
 
 	objJSON, err = json.Marshal(obj)
 	if err != nil {
-		// log.Printf("Cannot marshal object '%s': %s", obj, err)
 		return nil
 	}
 
@@ -234,10 +356,13 @@ func (wrb *WapiRequestBuilder) BuildBody(t RequestType, obj IBObject) []byte {
 	if t == GET && len(eaSearch) > 0 {
 		eaSearchJSON, err := json.Marshal(eaSearch)
 		if err != nil {
-			// log.Printf("Cannot marshal EA Search attributes. '%s'\n", err)
 			return nil
 		}
-		objJSON = append(append(objJSON[:len(objJSON)-1], byte(',')), eaSearchJSON[1:]...)
+		if len(objJSON) == 2 { // the object has no field set
+			objJSON = eaSearchJSON
+		} else {
+			objJSON = append(append(objJSON[:len(objJSON)-1], byte(',')), eaSearchJSON[1:]...)
+		}
 	}
 
 	return objJSON
@@ -245,6 +370,12 @@ func (wrb *WapiRequestBuilder) BuildBody(t RequestType, obj IBObject) []byte {
 
 // BuildRequest implements the construction of an HTTP request
 func (wrb *WapiRequestBuilder) BuildRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (req *http.Request, err error) {
+	return wrb.BuildRequestWithContext(context.Background(), t, obj, ref, queryParams)
+}
+
+// BuildRequestWithContext implements the construction of an HTTP request
+// bound to ctx
+func (wrb *WapiRequestBuilder) BuildRequestWithContext(ctx context.Context, t RequestType, obj IBObject, ref string, queryParams QueryParams) (req *http.Request, err error) {
 	var (
 		objType      string
 		returnFields []string
@@ -252,58 +383,152 @@ func (wrb *WapiRequestBuilder) BuildRequest(t RequestType, obj IBObject, ref str
 	if obj != nil {
 		objType = obj.ObjectType()
 		returnFields = obj.ReturnFields()
+		queryParams.query = obj.Query()
 	}
 	urlStr := wrb.BuildURL(t, objType, ref, returnFields, queryParams)
 
 	var bodyStr []byte
 	if obj != nil {
 		bodyStr = wrb.BuildBody(t, obj)
+		if bodyStr == nil {
+			return nil, fmt.Errorf("cannot marshal %s object", objType)
+		}
 	}
 
-	req, err = http.NewRequest(t.toMethod(), urlStr, bytes.NewBuffer(bodyStr))
+	req, err = http.NewRequestWithContext(ctx, t.toMethod(), urlStr, bytes.NewBuffer(bodyStr))
 	if err != nil {
-		// log.Printf("err1: '%s'", err)
 		return
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(wrb.HostConfig.Username, wrb.HostConfig.Password)
+	// with AuthSession the session cookie authenticates the request, the
+	// credentials are only sent to log in
+	if wrb.HostConfig.AuthMode != AuthSession || queryParams.authenticate {
+		var creds Credentials
+		creds, err = wrb.HostConfig.credentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get credentials: %w", err)
+		}
+		// a client certificate may authenticate the request on its own
+		if creds.Username != "" || creds.Password != "" {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+	}
 
 	return
 }
 
-func (c *Connector) makeRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
+// useProxyFallback reports whether a request which failed or found nothing
+// may be re-sent to the Grid Master
+func (c *Connector) useProxyFallback(t RequestType, queryParams QueryParams) bool {
+	return t == GET && !queryParams.forceProxy && c.TransportConfig.ProxySearch == ProxySearchFallback
+}
+
+func (c *Connector) makeRequest(ctx context.Context, t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
+	if t == GET && c.TransportConfig.ProxySearch == ProxySearchAlways {
+		queryParams.forceProxy = true
+	}
+
+	res, err = c.sendAuthenticated(ctx, t, obj, ref, queryParams)
+	if err != nil {
+		// a cancelled or expired context is final, don't retry against the Grid Master
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !c.useProxyFallback(t, queryParams) || !shouldProxyFallback(err) {
+			return
+		}
+		/* Forcing the request to redirect to Grid Master by making forcedProxy=true */
+		queryParams.forceProxy = true
+		loggerOrNop(c.Logger).Info("Retrying WAPI request through the Grid Master", "method", t.toMethod(), "ref", ref)
+		res, err = c.sendAuthenticated(ctx, t, obj, ref, queryParams)
+	}
+
+	return
+}
+
+// sendRequest builds and sends a single request, logging its outcome
+func (c *Connector) sendRequest(ctx context.Context, t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
+	logger := loggerOrNop(c.Logger)
+
 	var req *http.Request
-	req, err = c.RequestBuilder.BuildRequest(t, obj, ref, queryParams)
+	req, err = c.RequestBuilder.BuildRequestWithContext(ctx, t, obj, ref, queryParams)
 	if err != nil {
+		logger.Error("Cannot build WAPI request", "method", t.toMethod(), "ref", ref, "error", err)
 		return
 	}
-	res, err = c.Requestor.SendRequest(req)
+
+	info := &RequestInfo{
+		Method:      req.Method,
+		ObjectType:  objectTypeFromURL(req.URL),
+		URL:         redactURL(req.URL),
+		ProxySearch: queryParams.forceProxy,
+	}
+	ctx, stats := withRequestStats(ctx)
+	if c.Instrumentation != nil {
+		ctx = c.Instrumentation.RequestStarted(ctx, info)
+	}
+
+	start := time.Now()
+	res, err = c.Requestor.SendRequestWithContext(ctx, req)
+	info.Duration = time.Since(start)
+	info.StatusCode = stats.statusCode
+	info.Retries = stats.retries
+	info.Err = err
+
+	var wapiErr *WapiError
+	isWapiErr := errors.As(err, &wapiErr)
+	if info.StatusCode == 0 && isWapiErr {
+		// requestors other than WapiHTTPRequestor don't fill the stats
+		info.StatusCode = wapiErr.StatusCode
+	}
+	if c.Instrumentation != nil {
+		c.Instrumentation.RequestFinished(ctx, info)
+	}
+
+	kv := []interface{}{
+		"method", info.Method,
+		"url", info.URL,
+		"object_type", info.ObjectType,
+		"latency", info.Duration,
+	}
+	if info.Retries > 0 {
+		kv = append(kv, "retries", info.Retries)
+	}
 	if err != nil {
-		/* Forcing the request to redirect to Grid Master by making forcedProxy=true */
-		queryParams.forceProxy = true
-		req, err = c.RequestBuilder.BuildRequest(t, obj, ref, queryParams)
-		if err != nil {
-			return
+		kv = append(kv, "error", err)
+		if isWapiErr {
+			kv = append(kv, "status", wapiErr.StatusCode, "wapi_code", wapiErr.Code, "wapi_text", wapiErr.Text)
 		}
-		res, err = c.Requestor.SendRequest(req)
+		logger.Error("WAPI request failed", kv...)
+		return
 	}
+	logger.Debug("WAPI request", kv...)
 
 	return
 }
 
+func (c *Connector) logUnmarshalError(objType string, resp []byte, err error) {
+	loggerOrNop(c.Logger).Error("Cannot unmarshal WAPI response", "object_type", objType, "response", string(resp), "error", err)
+}
+
 // CreateObject makes a WAPI request to create the specified object
 func (c *Connector) CreateObject(obj IBObject) (ref string, err error) {
+	return c.CreateObjectWithContext(context.Background(), obj)
+}
+
+// CreateObjectWithContext makes a WAPI request, bound to ctx, to create the
+// specified object
+func (c *Connector) CreateObjectWithContext(ctx context.Context, obj IBObject) (ref string, err error) {
 	ref = ""
 	queryParams := QueryParams{forceProxy: false}
-	resp, err := c.makeRequest(CREATE, obj, "", queryParams)
+	resp, err := c.makeRequest(ctx, CREATE, obj, "", queryParams)
 	if err != nil || len(resp) == 0 {
-		// log.Printf("CreateObject request error: '%s'\n", err)
 		return
 	}
 
 	err = json.Unmarshal(resp, &ref)
 	if err != nil {
-		// log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		c.logUnmarshalError(obj.ObjectType(), resp, err)
 		return
 	}
 
@@ -312,8 +537,14 @@ func (c *Connector) CreateObject(obj IBObject) (ref string, err error) {
 
 // GetObject makes a WAPI request to get the specified object
 func (c *Connector) GetObject(obj IBObject, ref string, res interface{}) (err error) {
+	return c.GetObjectWithContext(context.Background(), obj, ref, res)
+}
+
+// GetObjectWithContext makes a WAPI request, bound to ctx, to get the
+// specified object
+func (c *Connector) GetObjectWithContext(ctx context.Context, obj IBObject, ref string, res interface{}) (err error) {
 	queryParams := QueryParams{forceProxy: false}
-	resp, err := c.makeRequest(GET, obj, ref, queryParams)
+	resp, err := c.makeRequest(ctx, GET, obj, ref, queryParams)
 	if err != nil {
 		return err
 	}
@@ -321,17 +552,17 @@ func (c *Connector) GetObject(obj IBObject, ref string, res interface{}) (err er
 	var result interface{}
 	err = json.Unmarshal(resp, &result)
 	if err != nil {
-		// log.Printf("Cannot unmarshall to check empty value '%s', err: '%s'\n", string(resp), err)
+		c.logUnmarshalError(obj.ObjectType(), resp, err)
 		return
 	}
 
 	var data []interface{}
-	if resp == nil || (reflect.TypeOf(result) == reflect.TypeOf(data) && len(result.([]interface{})) == 0) {
+	if c.useProxyFallback(GET, queryParams) &&
+		(resp == nil || (reflect.TypeOf(result) == reflect.TypeOf(data) && len(result.([]interface{})) == 0)) {
 		queryParams.forceProxy = true
-		resp, err = c.makeRequest(GET, obj, ref, queryParams)
+		resp, err = c.makeRequest(ctx, GET, obj, ref, queryParams)
 	}
 	if err != nil {
-		// log.Printf("GetObject request error: '%s'\n", err)
 		return
 	}
 	if len(resp) == 0 {
@@ -339,25 +570,71 @@ func (c *Connector) GetObject(obj IBObject, ref string, res interface{}) (err er
 	}
 	err = json.Unmarshal(resp, res)
 	if err != nil {
-		// log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		c.logUnmarshalError(obj.ObjectType(), resp, err)
 		return
 	}
 	return
 }
 
+// GetObjectPage makes a paged WAPI request for objects matching obj and
+// unmarshals at most maxResults of them into res. An empty pageID requests
+// the first page, the returned nextPageID is empty after the last page.
+func (c *Connector) GetObjectPage(obj IBObject, pageID string, maxResults int, res interface{}) (nextPageID string, err error) {
+	return c.GetObjectPageWithContext(context.Background(), obj, pageID, maxResults, res)
+}
+
+// GetObjectPageWithContext is like GetObjectPage but the request is bound to
+// ctx.
+func (c *Connector) GetObjectPageWithContext(ctx context.Context, obj IBObject, pageID string, maxResults int, res interface{}) (nextPageID string, err error) {
+	queryParams := QueryParams{
+		forceProxy: false,
+		paging:     true,
+		maxResults: maxResults,
+		pageID:     pageID,
+	}
+	resp, err := c.makeRequest(ctx, GET, obj, "", queryParams)
+	if err != nil {
+		return
+	}
+
+	page := struct {
+		Result     json.RawMessage `json:"result"`
+		NextPageID string          `json:"next_page_id"`
+	}{}
+	err = json.Unmarshal(resp, &page)
+	if err != nil {
+		c.logUnmarshalError(obj.ObjectType(), resp, err)
+		return
+	}
+	if len(page.Result) > 0 {
+		err = json.Unmarshal(page.Result, res)
+		if err != nil {
+			c.logUnmarshalError(obj.ObjectType(), page.Result, err)
+			return
+		}
+	}
+
+	return page.NextPageID, nil
+}
+
 // DeleteObject makes a WAPI request to delete the specified object
 func (c *Connector) DeleteObject(ref string) (refRes string, err error) {
+	return c.DeleteObjectWithContext(context.Background(), ref)
+}
+
+// DeleteObjectWithContext makes a WAPI request, bound to ctx, to delete the
+// specified object
+func (c *Connector) DeleteObjectWithContext(ctx context.Context, ref string) (refRes string, err error) {
 	refRes = ""
 	queryParams := QueryParams{forceProxy: false}
-	resp, err := c.makeRequest(DELETE, nil, ref, queryParams)
+	resp, err := c.makeRequest(ctx, DELETE, nil, ref, queryParams)
 	if err != nil {
-		// log.Printf("DeleteObject request error: '%s'\n", err)
 		return
 	}
 
 	err = json.Unmarshal(resp, &refRes)
 	if err != nil {
-		// log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		c.logUnmarshalError(ref, resp, err)
 		return
 	}
 
@@ -366,31 +643,76 @@ func (c *Connector) DeleteObject(ref string) (refRes string, err error) {
 
 // UpdateObject makes a WAPI request to update the specified object
 func (c *Connector) UpdateObject(obj IBObject, ref string) (refRes string, err error) {
+	return c.UpdateObjectWithContext(context.Background(), obj, ref)
+}
+
+// UpdateObjectWithContext makes a WAPI request, bound to ctx, to update the
+// specified object
+func (c *Connector) UpdateObjectWithContext(ctx context.Context, obj IBObject, ref string) (refRes string, err error) {
 	queryParams := QueryParams{forceProxy: false}
 	refRes = ""
-	resp, err := c.makeRequest(UPDATE, obj, ref, queryParams)
+	resp, err := c.makeRequest(ctx, UPDATE, obj, ref, queryParams)
 	if err != nil {
-		// log.Printf("Failed to update object %s: %s", obj.ObjectType(), err)
 		return
 	}
 
 	err = json.Unmarshal(resp, &refRes)
 	if err != nil {
-		// log.Printf("Cannot unmarshall update object response'%s', err: '%s'\n", string(resp), err)
+		c.logUnmarshalError(obj.ObjectType(), resp, err)
 		return
 	}
 	return
 }
 
+// FunctionCaller is implemented by connectors which can call WAPI
+// functions, such as Connector and test doubles
+type FunctionCaller interface {
+	CallFunctionWithContext(ctx context.Context, ref string, function string, args IBObject, res interface{}) error
+}
+
+// CallFunction makes a WAPI request calling function, e.g.
+// next_available_ip, on the object with reference ref with args as its
+// arguments, and unmarshals the result into res
+func (c *Connector) CallFunction(ref string, function string, args IBObject, res interface{}) error {
+	return c.CallFunctionWithContext(context.Background(), ref, function, args, res)
+}
+
+// CallFunctionWithContext is like CallFunction but the request is bound to
+// ctx.
+func (c *Connector) CallFunctionWithContext(ctx context.Context, ref string, function string, args IBObject, res interface{}) error {
+	queryParams := QueryParams{forceProxy: false, function: function}
+	resp, err := c.makeRequest(ctx, CREATE, args, ref, queryParams)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(resp, res)
+	if err != nil {
+		c.logUnmarshalError(args.ObjectType(), resp, err)
+	}
+	return err
+}
+
 // Logout sends a request to invalidate the ibapauth cookie and should
 // be used in a defer statement after the Connector has been successfully
-// initialized.
+// initialized. The session cookie is discarded even if the request fails.
 func (c *Connector) Logout() (err error) {
+	return c.LogoutWithContext(context.Background())
+}
+
+// LogoutWithContext is like Logout but the request is bound to ctx.
+func (c *Connector) LogoutWithContext(ctx context.Context) (err error) {
+	// there is nothing to invalidate, don't log in just to log out
+	if c.HostConfig.AuthMode == AuthSession && !c.hasSession() {
+		return nil
+	}
+
 	queryParams := QueryParams{forceProxy: false}
-	_, err = c.makeRequest(CREATE, nil, "logout", queryParams)
-	// if err != nil {
-	// 	log.Printf("Logout request error: '%s'\n", err)
-	// }
+	_, err = c.makeRequest(ctx, CREATE, nil, "logout", queryParams)
+	c.endSession()
+	if err != nil {
+		loggerOrNop(c.Logger).Error("Logout request failed", "host", c.HostConfig.Host, "error", err)
+	}
 
 	return
 }
@@ -403,9 +725,9 @@ func validateConnector(c *Connector) (err error) {
 	var response []UserProfile
 	userprofile := NewUserProfile(UserProfile{})
 	err = c.GetObject(userprofile, "", &response)
-	// if err != nil {
-	// 	log.Printf("Failed to connect to the Grid, err: %s \n", err)
-	// }
+	if err != nil {
+		loggerOrNop(c.Logger).Error("Failed to connect to the Grid", "host", c.HostConfig.Host, "error", err)
+	}
 	return
 }
 
@@ -1,7 +1,13 @@
 package ibclient
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
 )
 
 type FakeRequestBuilder struct {
@@ -29,6 +35,10 @@ func (rb *FakeRequestBuilder) BuildBody(r RequestType, obj IBObject) []byte {
 }
 
 func (rb *FakeRequestBuilder) BuildRequest(r RequestType, obj IBObject, ref string, queryParams QueryParams) (*http.Request, error) {
+	return rb.BuildRequestWithContext(context.Background(), r, obj, ref, queryParams)
+}
+
+func (rb *FakeRequestBuilder) BuildRequestWithContext(ctx context.Context, r RequestType, obj IBObject, ref string, queryParams QueryParams) (*http.Request, error) {
 	// Expect(r).To(Equal(rb.r))
 	// if rb.obj == nil {
 	// 	Expect(obj).To(BeNil())
@@ -52,15 +62,76 @@ func (hr *FakeHTTPRequestor) Init(config TransportConfig) {
 }
 
 func (hr *FakeHTTPRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	return hr.SendRequestWithContext(context.Background(), req)
+}
+
+func (hr *FakeHTTPRequestor) SendRequestWithContext(ctx context.Context, req *http.Request) ([]byte, error) {
 	// Expect(req).To(Equal(hr.req))
 
 	return hr.res, nil
 }
 
+var (
+	_ HTTPRequestBuilder = &FakeRequestBuilder{}
+	_ HTTPRequestor      = &FakeHTTPRequestor{}
+	_ HTTPRequestBuilder = &WapiRequestBuilder{}
+	_ HTTPRequestor      = &WapiHTTPRequestor{}
+)
+
 func MockValidateConnector(c *Connector) (err error) {
 	return
 }
 
+// newTestConnector returns a Connector talking to a TLS test server running
+// handler, the server is closed at the end of the test
+func newTestConnector(t *testing.T, handler http.HandlerFunc) *Connector {
+	ts := httptest.NewTLSServer(handler)
+	t.Cleanup(ts.Close)
+
+	u, _ := url.Parse(ts.URL)
+	hostCfg := HostConfig{Host: u.Hostname(), Port: u.Port(), Version: "2.5", Username: "admin", Password: "secret"}
+	rb := &WapiRequestBuilder{}
+	rb.Init(hostCfg)
+	hr := &WapiHTTPRequestor{}
+	hr.Init(NewTransportConfig("false", 5, 1))
+
+	return &Connector{HostConfig: hostCfg, RequestBuilder: rb, Requestor: hr}
+}
+
+func TestMakeRequestCancelledContext(t *testing.T) {
+	hostCfg := HostConfig{Host: "127.0.0.1", Version: "2.5", Port: "1"}
+	rb := &WapiRequestBuilder{}
+	rb.Init(hostCfg)
+	hr := &WapiHTTPRequestor{}
+	hr.Init(NewTransportConfig("false", 5, 1))
+	conn := &Connector{HostConfig: hostCfg, RequestBuilder: rb, Requestor: hr}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var res []NetworkView
+	err := conn.GetObjectWithContext(ctx, NewNetworkView(NetworkView{}), "", &res)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestBuildBodyEaSearch(t *testing.T) {
+	wrb := &WapiRequestBuilder{}
+
+	obj := NewRecordA(RecordA{Name: "www.example.com"})
+	obj.eaSearch = EASearch{"Site": "lab"}
+	if body := string(wrb.BuildBody(GET, obj)); body != `{"name":"www.example.com","*Site":"lab"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	obj = NewRecordA(RecordA{})
+	obj.eaSearch = EASearch{"Site": "lab"}
+	if body := string(wrb.BuildBody(GET, obj)); body != `{"*Site":"lab"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
 // var _ = Describe("Connector", func() {
 
 // 	Describe("WapiRequestBuilder", func() {
@@ -499,3 +570,25 @@ func MockValidateConnector(c *Connector) (err error) {
 
 // 	})
 // })
+
+func TestCallFunction(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/wapi/v2.5/network/ZG5z:10.0.0.0/24/default" ||
+			r.URL.RawQuery != "_function=next_available_ip" || string(body) != `{"num":2,"exclude":["10.0.0.1"]}` {
+			t.Errorf("unexpected request %s %s %s", r.Method, r.URL, body)
+		}
+		w.Write([]byte(`{"ips": ["10.0.0.2", "10.0.0.3"]}`))
+	})
+
+	var res struct {
+		IPs []string `json:"ips"`
+	}
+	args := NewNextAvailableIPArgs(NextAvailableIPArgs{Num: 2, Exclude: []string{"10.0.0.1"}})
+	if err := conn.CallFunction("network/ZG5z:10.0.0.0/24/default", "next_available_ip", args, &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.IPs) != 2 || res.IPs[1] != "10.0.0.3" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
@@ -0,0 +1,186 @@
+package ibclient
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are the user name and password sent to the Grid with Basic
+// Auth
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialProvider is the interface implemented by sources of
+// Credentials. It is called whenever a request needs the credentials, so
+// rotated credentials are picked up without rebuilding the Connector.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialProviderFunc adapts a function to the CredentialProvider
+// interface, e.g. to fetch the credentials from a secrets manager
+type CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials implements CredentialProvider
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a CredentialProvider always returning the given
+// user name and password
+func StaticCredentials(username, password string) CredentialProvider {
+	return CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		return Credentials{Username: username, Password: password}, nil
+	})
+}
+
+// Default environment variables read by EnvCredentials
+const (
+	DefaultUsernameEnv = "INFOBLOX_USERNAME"
+	DefaultPasswordEnv = "INFOBLOX_PASSWORD"
+)
+
+// EnvCredentials reads the credentials from environment variables
+type EnvCredentials struct {
+	// UsernameEnv defaults to DefaultUsernameEnv
+	UsernameEnv string
+	// PasswordEnv defaults to DefaultPasswordEnv
+	PasswordEnv string
+}
+
+// Credentials implements CredentialProvider
+func (ec EnvCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	userEnv, passEnv := ec.UsernameEnv, ec.PasswordEnv
+	if userEnv == "" {
+		userEnv = DefaultUsernameEnv
+	}
+	if passEnv == "" {
+		passEnv = DefaultPasswordEnv
+	}
+
+	username, ok := os.LookupEnv(userEnv)
+	if !ok {
+		return Credentials{}, fmt.Errorf("environment variable %s is not set", userEnv)
+	}
+	password, ok := os.LookupEnv(passEnv)
+	if !ok {
+		return Credentials{}, fmt.Errorf("environment variable %s is not set", passEnv)
+	}
+
+	return Credentials{Username: username, Password: password}, nil
+}
+
+// FileCredentials reads the credentials from files holding only the user
+// name or the password, such as mounted Kubernetes secrets. The files are
+// read on every call, trailing new lines are ignored.
+type FileCredentials struct {
+	UsernameFile string
+	PasswordFile string
+}
+
+// Credentials implements CredentialProvider
+func (fc FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	username, err := readSecretFile(fc.UsernameFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+	password, err := readSecretFile(fc.PasswordFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{Username: username, Password: password}, nil
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read credentials: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// CachedCredentials wraps a CredentialProvider, such as a secrets manager
+// client, so that it is called at most once per TTL
+type CachedCredentials struct {
+	provider CredentialProvider
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	creds   Credentials
+	expires time.Time
+}
+
+// NewCachedCredentials returns a CachedCredentials keeping the credentials
+// of provider for ttl
+func NewCachedCredentials(provider CredentialProvider, ttl time.Duration) *CachedCredentials {
+	return &CachedCredentials{provider: provider, ttl: ttl}
+}
+
+// Credentials implements CredentialProvider
+func (cc *CachedCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if time.Now().Before(cc.expires) {
+		return cc.creds, nil
+	}
+
+	creds, err := cc.provider.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	cc.creds = creds
+	cc.expires = time.Now().Add(cc.ttl)
+
+	return creds, nil
+}
+
+// Invalidate drops the cached credentials, the next call fetches them again.
+// The Connector calls it when the Grid rejects the credentials, so rotated
+// ones are picked up before the TTL expires.
+func (cc *CachedCredentials) Invalidate() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.expires = time.Time{}
+}
+
+// credentials returns the Credentials of the CredentialProvider, or the
+// static Username and Password if there is none
+func (hc HostConfig) credentials(ctx context.Context) (Credentials, error) {
+	if hc.Credentials == nil {
+		return Credentials{Username: hc.Username, Password: hc.Password}, nil
+	}
+	return hc.Credentials.Credentials(ctx)
+}
+
+// LoadClientCertificate reads a PEM encoded certificate and private key
+// pair from files and adds it to the client certificates presented to the
+// Grid
+func (cfg *TransportConfig) LoadClientCertificate(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot load client certificate: %w", err)
+	}
+	cfg.ClientCertificates = append(cfg.ClientCertificates, cert)
+	return nil
+}
+
+// AddClientCertificatePEM parses a PEM encoded certificate and private key
+// pair and adds it to the client certificates presented to the Grid
+func (cfg *TransportConfig) AddClientCertificatePEM(certPEM, keyPEM []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("cannot parse client certificate: %w", err)
+	}
+	cfg.ClientCertificates = append(cfg.ClientCertificates, cert)
+	return nil
+}
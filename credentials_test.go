@@ -0,0 +1,180 @@
+package ibclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	for k, v := range map[string]string{"IB_TEST_USER": "admin", "IB_TEST_PASS": "secret"} {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	creds, err := EnvCredentials{UsernameEnv: "IB_TEST_USER", PasswordEnv: "IB_TEST_PASS"}.Credentials(context.Background())
+	if err != nil || creds != (Credentials{Username: "admin", Password: "secret"}) {
+		t.Errorf("unexpected credentials %+v, error %v", creds, err)
+	}
+
+	if _, err := (EnvCredentials{UsernameEnv: "IB_TEST_USER", PasswordEnv: "IB_TEST_UNSET"}).Credentials(context.Background()); err == nil {
+		t.Error("expected an error for an unset variable")
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir := t.TempDir()
+	userFile, passFile := filepath.Join(dir, "username"), filepath.Join(dir, "password")
+	_ = ioutil.WriteFile(userFile, []byte("admin\n"), 0600)
+	_ = ioutil.WriteFile(passFile, []byte("secret"), 0600)
+
+	creds, err := FileCredentials{UsernameFile: userFile, PasswordFile: passFile}.Credentials(context.Background())
+	if err != nil || creds != (Credentials{Username: "admin", Password: "secret"}) {
+		t.Errorf("unexpected credentials %+v, error %v", creds, err)
+	}
+}
+
+func TestCachedCredentials(t *testing.T) {
+	calls := 0
+	cc := NewCachedCredentials(CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		calls++
+		return Credentials{Username: "admin"}, nil
+	}), time.Hour)
+
+	_, _ = cc.Credentials(context.Background())
+	_, _ = cc.Credentials(context.Background())
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+
+	cc.Invalidate()
+	_, _ = cc.Credentials(context.Background())
+	if calls != 2 {
+		t.Errorf("expected a call after Invalidate, got %d", calls)
+	}
+}
+
+func TestBuildRequestUsesCredentialProvider(t *testing.T) {
+	password := "first"
+	hostCfg := HostConfig{Host: "grid", Port: "443", Version: "2.5",
+		Credentials: CredentialProviderFunc(func(context.Context) (Credentials, error) {
+			return Credentials{Username: "admin", Password: password}, nil
+		})}
+	wrb := &WapiRequestBuilder{}
+	wrb.Init(hostCfg)
+
+	for _, expected := range []string{"first", "rotated"} {
+		password = expected
+		req, err := wrb.BuildRequest(GET, NewUserProfile(UserProfile{}), "", QueryParams{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, pass, _ := req.BasicAuth(); pass != expected {
+			t.Errorf("got password %q, expected %q", pass, expected)
+		}
+	}
+
+	hostCfg.Credentials = CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		return Credentials{}, errors.New("vault sealed")
+	})
+	wrb.Init(hostCfg)
+	if _, err := wrb.BuildRequest(GET, NewUserProfile(UserProfile{}), "", QueryParams{}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func newTestKeyPair(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestClientCertificate(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	ts.StartTLS()
+	defer ts.Close()
+
+	certPEM, keyPEM := newTestKeyPair(t)
+	cfg := NewTransportConfig("false", 5, 1)
+	if err := cfg.AddClientCertificatePEM(certPEM, keyPEM); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hr := &WapiHTTPRequestor{}
+	hr.Init(cfg)
+
+	req, _ := http.NewRequest("GET", ts.URL+"/wapi/v2.5/grid", nil)
+	if _, err := hr.SendRequest(req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := cfg.AddClientCertificatePEM(certPEM, []byte("not a key")); err == nil {
+		t.Error("expected an error for an invalid key")
+	}
+}
+
+func TestRotatedCredentialsAreFetchedAgain(t *testing.T) {
+	for _, mode := range []AuthMode{AuthBasic, AuthSession} {
+		password := "old"
+		cc := NewCachedCredentials(CredentialProviderFunc(func(context.Context) (Credentials, error) {
+			return Credentials{Username: "admin", Password: password}, nil
+		}), time.Hour)
+		_, _ = cc.Credentials(context.Background())
+		password = "rotated"
+
+		conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+			if _, pass, _ := r.BasicAuth(); pass != "rotated" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		})
+		conn.HostConfig.AuthMode = mode
+		conn.HostConfig.Credentials = cc
+		conn.RequestBuilder.Init(conn.HostConfig)
+
+		var res []UserProfile
+		if err := conn.GetObject(NewUserProfile(UserProfile{}), "", &res); err != nil {
+			t.Errorf("auth mode %v: unexpected error: %v", mode, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Sentinel errors which a *WapiError matches with errors.Is
+var (
+	// ErrNotFound indicates the requested object or reference does not exist
+	ErrNotFound = errors.New("object not found")
+	// ErrDuplicate indicates the object conflicts with an existing one
+	ErrDuplicate = errors.New("duplicate object")
+	// ErrUnauthorized indicates the credentials were missing or rejected
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPermissionDenied indicates the user may not perform the operation
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// WapiError is returned for any request which WAPI answers with an
+// unexpected HTTP status. The Error, Code, Text and Trace fields are parsed
+// from the JSON error body when WAPI provides one.
+type WapiError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Method     string `json:"-"`
+	ObjectType string `json:"-"`
+	Body       []byte `json:"-"`
+
+	Err   string `json:"Error"`
+	Code  string `json:"code"`
+	Text  string `json:"text"`
+	Trace string `json:"trace"`
+}
+
+// Error implements the error interface
+func (e *WapiError) Error() string {
+	msg := e.Text
+	if msg == "" {
+		msg = e.Err
+	}
+	if msg == "" {
+		msg = strings.TrimSpace(string(e.Body))
+	}
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s: %s", e.Code, msg)
+	}
+
+	return fmt.Sprintf("WAPI request error: %s %s: %d('%s'): %s", e.Method, e.ObjectType, e.StatusCode, e.Status, msg)
+}
+
+// Is reports whether the error matches one of the package sentinel errors
+func (e *WapiError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound ||
+			e.Code == "Client.Ibap.Data.NotFound" ||
+			strings.Contains(e.Err, "NotFoundError")
+	case ErrDuplicate:
+		return e.Code == "Client.Ibap.Data.Conflict" ||
+			strings.Contains(e.Text, "already exists")
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPermissionDenied:
+		return e.StatusCode == http.StatusForbidden ||
+			e.Code == "Client.Ibap.Auth" ||
+			strings.Contains(e.Err, "PermissionDenied")
+	}
+
+	return false
+}
+
+// NewWapiError builds a WapiError from the status code and body of a WAPI
+// response, parsing the JSON error fields if present
+func NewWapiError(method string, objType string, statusCode int, body []byte) *WapiError {
+	wapiErr := &WapiError{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Method:     method,
+		ObjectType: objType,
+		Body:       body,
+	}
+	// the body is HTML rather than JSON for some errors (e.g. 401), the
+	// parsed fields are then left empty
+	_ = json.Unmarshal(body, wapiErr)
+
+	return wapiErr
+}
+
+// objectTypeFromURL extracts the object type from a WAPI URL path such as
+// /wapi/v2.5/record:a/ZG5z...:a.example.com/default
+func objectTypeFromURL(u *url.URL) string {
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 4)
+	if len(parts) < 3 || parts[0] != "wapi" {
+		return ""
+	}
+
+	return parts[2]
+}
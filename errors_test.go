@@ -0,0 +1,79 @@
+package ibclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWapiErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		target error
+	}{
+		{"not found by code", http.StatusBadRequest,
+			`{"Error": "AdmConDataNotFoundError: Reference record:a/xyz not found", "code": "Client.Ibap.Data.NotFound", "text": "Reference record:a/xyz not found"}`,
+			ErrNotFound},
+		{"not found by status", http.StatusNotFound, ``, ErrNotFound},
+		{"duplicate", http.StatusBadRequest,
+			`{"Error": "AdmConDataError: None (IBDataConflictError: IB.Data.Conflict:The record 'a.example.com' already exists.)", "code": "Client.Ibap.Data.Conflict", "text": "The record 'a.example.com' already exists."}`,
+			ErrDuplicate},
+		{"unauthorized", http.StatusUnauthorized, `<html>Authorization Required</html>`, ErrUnauthorized},
+		{"permission denied", http.StatusForbidden, ``, ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := error(NewWapiError("GET", "record:a", tt.status, []byte(tt.body)))
+			if !errors.Is(err, tt.target) {
+				t.Errorf("expected %v to match %v", err, tt.target)
+			}
+			for _, other := range []error{ErrNotFound, ErrDuplicate, ErrUnauthorized, ErrPermissionDenied} {
+				if other != tt.target && errors.Is(err, other) {
+					t.Errorf("%v unexpectedly matches %v", err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestSendRequestReturnsWapiError(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"Error": "AdmConProtoError: Unknown argument/field: 'foo'", "code": "Client.Ibap.Proto", "text": "Unknown argument/field: 'foo'", "trace": "  File ..."}`))
+	}))
+	defer ts.Close()
+
+	hr := &WapiHTTPRequestor{}
+	hr.Init(NewTransportConfig("false", 5, 1))
+	req, _ := http.NewRequest("GET", ts.URL+"/wapi/v2.5/record:host?foo=bar", nil)
+
+	_, err := hr.SendRequest(req)
+	var wapiErr *WapiError
+	if !errors.As(err, &wapiErr) {
+		t.Fatalf("expected a *WapiError, got %T: %v", err, err)
+	}
+	if wapiErr.StatusCode != http.StatusBadRequest || wapiErr.Method != "GET" ||
+		wapiErr.ObjectType != "record:host" || wapiErr.Code != "Client.Ibap.Proto" ||
+		wapiErr.Text != "Unknown argument/field: 'foo'" || wapiErr.Trace == "" {
+		t.Errorf("unexpected error fields: %+v", wapiErr)
+	}
+}
+
+func TestObjectTypeFromURL(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"https://h:443/wapi/v2.5/network":                                              "network",
+		"https://h:443/wapi/v2.5/record:a/ZG5zLmJpbmRfYSQ:a.example.com/default":       "record:a",
+		"https://h:443/wapi/v2.5/fixedaddress/ZG5zLmJpbmRfY25h:12.0.10.1/external?x=1": "fixedaddress",
+		"https://h:443/other": "",
+	} {
+		u, _ := url.Parse(raw)
+		if actual := objectTypeFromURL(u); actual != expected {
+			t.Errorf("objectTypeFromURL(%q) = %q, expected %q", raw, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ibclient
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ObjectPtr is satisfied by pointers to the WAPI object structs, which
+// embed IBBase, such as *Network or *RecordA
+type ObjectPtr[T any] interface {
+	*T
+	IBObject
+	base() *IBBase
+}
+
+func (obj *IBBase) base() *IBBase {
+	return obj
+}
+
+// NewIBBase returns the IBBase of a WAPI object of type objType, read with
+// returnFields. It lets types defined outside this package, which embed
+// IBBase, be used with the generic functions such as Get and List.
+//
+//	type RecordDNAME struct {
+//		ibclient.IBBase `json:"-"`
+//		Ref    string `json:"_ref,omitempty"`
+//		Name   string `json:"name,omitempty"`
+//		Target string `json:"target,omitempty"`
+//	}
+//
+//	obj := &RecordDNAME{IBBase: ibclient.NewIBBase("record:dname", []string{"name", "target"})}
+func NewIBBase(objType string, returnFields []string) IBBase {
+	return IBBase{objectType: objType, returnFields: returnFields}
+}
+
+// newResult returns an empty object of the type and return fields of obj,
+// without its search arguments
+func newResult[T any, PT ObjectPtr[T]](obj PT) PT {
+	res := PT(new(T))
+	*res.base() = IBBase{objectType: obj.ObjectType(), returnFields: obj.ReturnFields()}
+	return res
+}
+
+// Get reads the object with reference ref, of the type of obj, e.g.
+//
+//	network, err := Get(conn, NewNetwork(Network{}), ref)
+//
+// If ref is empty, the fields, extensible attributes and query of obj are
+// searched instead and the first match is returned. ErrNotFound is returned
+// if there is no such object.
+func Get[T any, PT ObjectPtr[T]](conn IBConnector, obj PT, ref string) (PT, error) {
+	return GetWithContext[T, PT](context.Background(), conn, obj, ref)
+}
+
+// GetWithContext is like Get but uses ctx for the WAPI request.
+func GetWithContext[T any, PT ObjectPtr[T]](ctx context.Context, conn IBConnector, obj PT, ref string) (PT, error) {
+	if ref != "" {
+		res := newResult[T](obj)
+		if err := conn.GetObjectWithContext(ctx, obj, ref, res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	list, err := ListWithContext[T, PT](ctx, conn, obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%w: no %s matches the search", ErrNotFound, obj.ObjectType())
+	}
+	res := PT(&list[0])
+	*res.base() = *newResult[T](obj).base()
+	return res, nil
+}
+
+// List returns the objects matching the fields, extensible attributes and
+// query of obj, all of them if none are set
+//
+//	networks, err := List(conn, NewNetwork(Network{NetviewName: "default"}))
+func List[T any, PT ObjectPtr[T]](conn IBConnector, obj PT) ([]T, error) {
+	return ListWithContext[T, PT](context.Background(), conn, obj)
+}
+
+// ListWithContext is like List but uses ctx for the WAPI request.
+func ListWithContext[T any, PT ObjectPtr[T]](ctx context.Context, conn IBConnector, obj PT) ([]T, error) {
+	var res []T
+	if err := conn.GetObjectWithContext(ctx, obj, "", &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListAll is like List but fetches the objects pageSize at a time, so the
+// results are not limited by the maximum result set size of WAPI
+func ListAll[T any, PT ObjectPtr[T]](conn IBConnector, obj PT, pageSize int) ([]T, error) {
+	return ListAllWithContext[T, PT](context.Background(), conn, obj, pageSize)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the WAPI requests.
+func ListAllWithContext[T any, PT ObjectPtr[T]](ctx context.Context, conn IBConnector, obj PT, pageSize int) ([]T, error) {
+	var res []T
+	if err := GetAllObjectsWithContext(ctx, conn, obj, pageSize, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Create creates obj and sets its Ref field, if it has one, to the
+// reference of the new object
+func Create[T any, PT ObjectPtr[T]](conn IBConnector, obj PT) (PT, error) {
+	return CreateWithContext[T, PT](context.Background(), conn, obj)
+}
+
+// CreateWithContext is like Create but uses ctx for the WAPI request.
+func CreateWithContext[T any, PT ObjectPtr[T]](ctx context.Context, conn IBConnector, obj PT) (PT, error) {
+	ref, err := conn.CreateObjectWithContext(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	setRef(obj, ref)
+	return obj, nil
+}
+
+// Update writes the fields set in obj to the object with reference ref and
+// sets the Ref field of obj, if it has one, to the returned reference,
+// which changes when the name of the object does
+func Update[T any, PT ObjectPtr[T]](conn IBConnector, obj PT, ref string) (PT, error) {
+	return UpdateWithContext[T, PT](context.Background(), conn, obj, ref)
+}
+
+// UpdateWithContext is like Update but uses ctx for the WAPI request.
+func UpdateWithContext[T any, PT ObjectPtr[T]](ctx context.Context, conn IBConnector, obj PT, ref string) (PT, error) {
+	newRef, err := conn.UpdateObjectWithContext(ctx, clearRef(obj), ref)
+	if err != nil {
+		return nil, err
+	}
+	setRef(obj, newRef)
+	return obj, nil
+}
+
+// Delete deletes the object with reference ref
+func Delete(conn IBConnector, ref string) (string, error) {
+	return DeleteWithContext(context.Background(), conn, ref)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the WAPI request.
+func DeleteWithContext(ctx context.Context, conn IBConnector, ref string) (string, error) {
+	return conn.DeleteObjectWithContext(ctx, ref)
+}
+
+// refField returns the field of obj holding its reference, the one tagged
+// _ref, if any
+func refField(obj interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if f.Type.Kind() == reflect.String && name == "_ref" {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func setRef(obj interface{}, ref string) {
+	if f, ok := refField(obj); ok && f.CanSet() {
+		f.SetString(ref)
+	}
+}
+
+// clearRef returns a copy of obj without its reference, which WAPI
+// doesn't accept in the body of updates
+func clearRef[T any, PT ObjectPtr[T]](obj PT) PT {
+	f, ok := refField(obj)
+	if !ok || f.String() == "" {
+		return obj
+	}
+	cp := PT(new(T))
+	*cp = *obj
+	setRef(cp, "")
+	return cp
+}
@@ -0,0 +1,75 @@
+package ibclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGenericGet(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/network/a:10.0.0.0/24/default"):
+			_, _ = w.Write([]byte(`{"_ref": "network/a:10.0.0.0/24/default", "network": "10.0.0.0/24", "network_view": "default"}`))
+		case strings.HasSuffix(r.URL.Path, "/network") && r.URL.Query().Get("network~") == "^10":
+			_, _ = w.Write([]byte(`[{"_ref": "network/a:10.0.0.0/24/default", "network": "10.0.0.0/24"}]`))
+		case strings.HasSuffix(r.URL.Path, "/network"):
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"Error": "AdmConDataNotFoundError", "code": "Client.Ibap.Data.NotFound", "text": "Reference not found"}`))
+		}
+	})
+
+	network, err := Get(conn, NewNetwork(Network{}), "network/a:10.0.0.0/24/default")
+	if err != nil || network.Cidr != "10.0.0.0/24" || network.ObjectType() != "network" {
+		t.Errorf("unexpected network %+v, error %v", network, err)
+	}
+
+	obj := NewNetwork(Network{})
+	obj.SetQuery(NewQuery().Regex("network", "^10"))
+	if network, err = Get(conn, obj, ""); err != nil || network.Ref != "network/a:10.0.0.0/24/default" || network.Query() != nil {
+		t.Errorf("unexpected network %+v, error %v", network, err)
+	}
+
+	if _, err := Get(conn, NewNetwork(Network{Cidr: "10.1.0.0/24"}), ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, err := Get(conn, NewNetwork(Network{}), "network/b:10.1.0.0/24/default"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestGenericCreateUpdateDelete(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`"record:cname/a:www.example.com/default"`))
+		case http.MethodPut:
+			if strings.Contains(string(body), "_ref") {
+				t.Errorf("unexpected reference in update body %s", body)
+			}
+			_, _ = w.Write([]byte(`"record:cname/a:web.example.com/default"`))
+		case http.MethodDelete:
+			_, _ = w.Write([]byte(`"record:cname/a:web.example.com/default"`))
+		}
+	})
+
+	rec, err := Create(conn, NewRecordCNAME(RecordCNAME{Name: "www.example.com", Canonical: "example.com"}))
+	if err != nil || rec.Ref != "record:cname/a:www.example.com/default" {
+		t.Fatalf("unexpected record %+v, error %v", rec, err)
+	}
+
+	rec.Name = "web.example.com"
+	if rec, err = Update(conn, rec, rec.Ref); err != nil || rec.Ref != "record:cname/a:web.example.com/default" {
+		t.Fatalf("unexpected record %+v, error %v", rec, err)
+	}
+
+	if ref, err := Delete(conn, rec.Ref); err != nil || ref != rec.Ref {
+		t.Errorf("unexpected reference %q, error %v", ref, err)
+	}
+}
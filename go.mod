@@ -1,5 +1,5 @@
 module jimrazmus/infoblox-go-client
 
-go 1.15
+go 1.18
 
 require golang.org/x/net v0.0.0-20210226172049-e18ecbb05110
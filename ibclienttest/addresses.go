@@ -0,0 +1,250 @@
+package ibclienttest
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+)
+
+// ipAddressKinds are the ipv4address and ipv6address objects, which are
+// not stored but computed from the objects using each address
+var ipAddressKinds = map[string]objectKind{
+	"ipv4address": {
+		defaultFields: []string{"ip_address", "is_conflict", "mac_address", "names", "network", "network_view",
+			"objects", "status", "types", "usage"},
+	},
+	"ipv6address": {
+		defaultFields: []string{"duid", "ip_address", "is_conflict", "names", "network", "network_view",
+			"objects", "status", "types", "usage"},
+	},
+}
+
+// maxNetworkAddresses is the size of the largest IPv4 network whose
+// addresses can be searched
+const maxNetworkAddresses = 1 << 16
+
+// ipAddressFields are the fields of the address in the objects using it
+var ipAddressFields = map[string]struct {
+	addr, fixedAddress, record string
+}{
+	"ipv4address": {"ipv4addr", "fixedaddress", "record:a"},
+	"ipv6address": {"ipv6addr", "ipv6fixedaddress", "record:aaaa"},
+}
+
+// searchIPAddresses returns the ipv4address or ipv6address objects of the
+// network or ip_address searched, one of which is required as with WAPI.
+// The unused addresses of IPv6 networks are not returned.
+func (s *Simulator) searchIPAddresses(objType string, conds []condition) ([]*object, error) {
+	netview, network, ip := "default", "", ""
+	for _, c := range conds {
+		if c.ea || c.mods != "" {
+			continue
+		}
+		switch c.field {
+		case "network_view":
+			netview = formatValue(c.value)
+		case "network":
+			network = formatValue(c.value)
+		case "ip_address":
+			ip = formatValue(canonicalIP(c.value))
+		}
+	}
+
+	if ip != "" {
+		if obj := s.ipAddress(objType, ip, netview); obj != nil {
+			return []*object{obj}, nil
+		}
+		return nil, nil
+	}
+	if network == "" {
+		return nil, protoError("Either network or ip_address is required to search " + objType)
+	}
+
+	_, ipNet, err := net.ParseCIDR(network)
+	if err != nil || (ipNet.IP.To4() == nil) != (objType == "ipv6address") {
+		return nil, dataError(fmt.Sprintf("None (IBDataError: IB.Data:Invalid network %s)", network))
+	}
+
+	var ips []string
+	if objType == "ipv6address" {
+		ips = s.usedIPv6Addresses(ipNet)
+	} else {
+		first, last := addressRange(ipNet)
+		size := new(big.Int).Sub(last, first)
+		if size.Cmp(big.NewInt(maxNetworkAddresses)) >= 0 {
+			return nil, protoError(fmt.Sprintf("Network %s has too many addresses to be searched", network))
+		}
+		for i := first; i.Cmp(last) <= 0; i = new(big.Int).Add(i, big.NewInt(1)) {
+			ips = append(ips, intToIP(i, 32).String())
+		}
+	}
+
+	var res []*object
+	for _, ip := range ips {
+		if obj := s.ipAddress(objType, ip, netview); obj != nil {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+// usedIPv6Addresses returns the addresses of ipNet used by an object
+func (s *Simulator) usedIPv6Addresses(ipNet *net.IPNet) []string {
+	seen := make(map[string]bool)
+	var res []string
+	add := func(v interface{}) {
+		ip := net.ParseIP(formatValue(v))
+		if ip != nil && ip.To4() == nil && ipNet.Contains(ip) && !seen[ip.String()] {
+			seen[ip.String()] = true
+			res = append(res, ip.String())
+		}
+	}
+	for _, obj := range s.list("ipv6fixedaddress") {
+		add(obj.fields["ipv6addr"])
+	}
+	for _, obj := range s.hostAddrs("ipv6addr") {
+		add(obj.fields["ipv6addr"])
+	}
+	for _, objType := range []string{"record:aaaa", "record:ptr"} {
+		for _, obj := range s.list(objType) {
+			add(obj.fields["ipv6addr"])
+		}
+	}
+	for _, obj := range s.list("lease") {
+		add(obj.fields["address"])
+	}
+	return res
+}
+
+// ipAddress returns the ipv4address or ipv6address object of ip, or nil if
+// ip isn't in a network of netview
+func (s *Simulator) ipAddress(objType string, ip string, netview string) *object {
+	n := s.containingNetwork(ip, netview)
+	if n == nil || (objType == "ipv6address") != (n.objType == "ipv6network") {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"ip_address":   ip,
+		"network":      n.fields["network"],
+		"network_view": netview,
+		"is_conflict":  false,
+	}
+	var types, objects, names, usage []interface{}
+	use := func(typ string, ref string, name interface{}, use string) {
+		types = appendUnique(types, typ)
+		if ref != "" {
+			objects = append(objects, ref)
+		}
+		if name != nil {
+			names = appendUnique(names, name)
+		}
+		usage = appendUnique(usage, use)
+	}
+
+	f := ipAddressFields[objType]
+	if _, ipNet, err := net.ParseCIDR(formatValue(n.fields["network"])); err == nil && objType == "ipv4address" {
+		first, last := addressRange(ipNet)
+		switch {
+		case ip == intToIP(first, 32).String():
+			use("NETWORK", n.ref, nil, "DHCP")
+		case ip == intToIP(last, 32).String():
+			use("BROADCAST", n.ref, nil, "DHCP")
+		}
+	}
+
+	sameAddr := func(obj *object, field string) bool {
+		return formatValue(canonicalIP(obj.fields[field])) == ip
+	}
+	for _, obj := range s.list(f.fixedAddress) {
+		if sameAddr(obj, f.addr) && formatValue(obj.fields["network_view"]) == netview {
+			use("FIXEDADDRESS", obj.ref, obj.fields["name"], "DHCP")
+			if mac, ok := obj.fields["mac"]; ok {
+				fields["mac_address"] = mac
+			}
+			if duid, ok := obj.fields["duid"]; ok {
+				fields["duid"] = duid
+			}
+		}
+	}
+	for _, host := range s.list("record:host") {
+		addrs, _ := host.fields[f.addr+"s"].([]interface{})
+		for _, a := range addrs {
+			if addr, ok := a.(map[string]interface{}); ok && formatValue(canonicalIP(addr[f.addr])) == ip {
+				use("HOST", host.ref, host.fields["name"], "DNS")
+			}
+		}
+	}
+	for _, obj := range s.list(f.record) {
+		if sameAddr(obj, f.addr) {
+			use(strings.ToUpper(strings.TrimPrefix(f.record, "record:")), obj.ref, obj.fields["name"], "DNS")
+		}
+	}
+	for _, obj := range s.list("record:ptr") {
+		if sameAddr(obj, f.addr) {
+			use("PTR", obj.ref, obj.fields["ptrdname"], "DNS")
+		}
+	}
+	for _, obj := range s.list("lease") {
+		if sameAddr(obj, "address") && obj.fields["network_view"] == netview &&
+			formatValue(obj.fields["binding_state"]) == "ACTIVE" {
+			use("LEASE", obj.ref, obj.fields["client_hostname"], "DHCP")
+			fields["lease_state"] = "ACTIVE"
+			if mac, ok := fields["mac_address"]; ok && obj.fields["hardware"] != nil && mac != obj.fields["hardware"] {
+				fields["is_conflict"] = true
+				fields["conflict_types"] = []interface{}{"MAC_ADDRESS"}
+			} else if !ok && obj.fields["hardware"] != nil {
+				fields["mac_address"] = obj.fields["hardware"]
+			}
+		}
+	}
+
+	fields["status"] = "UNUSED"
+	if len(types) > 0 {
+		fields["status"] = "USED"
+	}
+	fields["types"] = nonNil(types)
+	fields["objects"] = nonNil(objects)
+	fields["names"] = nonNil(names)
+	fields["usage"] = nonNil(usage)
+
+	id := newID(objType+"$"+ip+"/"+netview, 0)
+	return &object{objType: objType, id: id, ref: objType + "/" + id + ":" + ip + "/" + netview, fields: fields}
+}
+
+// readIPAddress returns the ipv4address or ipv6address object with the
+// given reference
+func (s *Simulator) readIPAddress(ref string) *object {
+	i := strings.Index(ref, ":")
+	if i < 0 {
+		return nil
+	}
+	objType := ref[:strings.Index(ref, "/")]
+	parts := strings.SplitN(ref[i+1:], "/", 2)
+	netview := "default"
+	if len(parts) == 2 {
+		netview = parts[1]
+	}
+	obj := s.ipAddress(objType, formatValue(canonicalIP(parts[0])), netview)
+	if obj == nil || obj.ref != ref {
+		return nil
+	}
+	return obj
+}
+
+func appendUnique(list []interface{}, v interface{}) []interface{} {
+	for _, e := range list {
+		if e == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func nonNil(list []interface{}) []interface{} {
+	if list == nil {
+		return []interface{}{}
+	}
+	return list
+}
@@ -0,0 +1,72 @@
+package ibclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// wapiError is answered with the status and JSON body of a WAPI error
+type wapiError struct {
+	status int
+	Err    string `json:"Error"`
+	Code   string `json:"code"`
+	Text   string `json:"text"`
+}
+
+func (e *wapiError) Error() string {
+	return e.Err
+}
+
+func protoError(text string) error {
+	return &wapiError{
+		status: http.StatusBadRequest,
+		Err:    "AdmConProtoError: " + text,
+		Code:   "Client.Ibap.Proto",
+		Text:   text,
+	}
+}
+
+func notFoundError(ref string) error {
+	text := fmt.Sprintf("Reference %s not found", ref)
+	return &wapiError{
+		status: http.StatusNotFound,
+		Err:    "AdmConDataNotFoundError: " + text,
+		Code:   "Client.Ibap.Data.NotFound",
+		Text:   text,
+	}
+}
+
+func conflictError(text string) error {
+	return &wapiError{
+		status: http.StatusBadRequest,
+		Err:    fmt.Sprintf("AdmConDataError: None (IBDataConflictError: IB.Data.Conflict:%s)", text),
+		Code:   "Client.Ibap.Data.Conflict",
+		Text:   text,
+	}
+}
+
+func dataError(text string) error {
+	return &wapiError{
+		status: http.StatusBadRequest,
+		Err:    "AdmConDataError: " + text,
+		Code:   "Client.Ibap.Data",
+		Text:   text,
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	wapiErr, ok := err.(*wapiError)
+	if !ok {
+		wapiErr = &wapiError{
+			status: http.StatusInternalServerError,
+			Err:    "AdmConInternalError: " + err.Error(),
+			Code:   "Server",
+			Text:   err.Error(),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(wapiErr.status)
+	_ = json.NewEncoder(w).Encode(wapiErr)
+}
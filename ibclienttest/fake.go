@@ -0,0 +1,434 @@
+package ibclienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	ibclient "jimrazmus/infoblox-go-client"
+)
+
+// Call is a call received by a FakeConnector
+type Call struct {
+	Op         ibclient.RequestType
+	ObjectType string
+	// Object is nil for deletions
+	Object       ibclient.IBObject
+	Ref          string
+	ReturnFields []string
+	// Query holds the search arguments of the object's Query, if any
+	Query      url.Values
+	PageID     string
+	MaxResults int
+	// Function is the WAPI function of function calls, which are CREATE
+	// calls on the object type of Ref with the arguments as Object
+	Function string
+}
+
+// Stub is a scripted response of a FakeConnector, see FakeConnector.On
+type Stub struct {
+	op      ibclient.RequestType
+	objType string
+	ref     string
+
+	result     interface{}
+	nextPageID string
+	err        error
+	times      int // unlimited if 0
+	calls      int
+}
+
+// Return sets the result of the matched calls. For GET calls it is copied
+// into the result argument: it can be a value of the same type, such as a
+// []ibclient.Network for a search, raw JSON as a []byte or anything
+// marshaled to the same JSON. For other calls it is the returned reference,
+// or the results of a MultiRequest or function call, copied likewise.
+func (s *Stub) Return(result interface{}) *Stub {
+	s.result = result
+	return s
+}
+
+// ReturnPage is like Return for GetObjectPage calls, which also return the
+// ID of the next page
+func (s *Stub) ReturnPage(result interface{}, nextPageID string) *Stub {
+	s.result = result
+	s.nextPageID = nextPageID
+	return s
+}
+
+// ReturnError makes the matched calls fail with err
+func (s *Stub) ReturnError(err error) *Stub {
+	s.err = err
+	return s
+}
+
+// Times limits the stub to n calls, after which it no longer matches
+func (s *Stub) Times(n int) *Stub {
+	s.times = n
+	return s
+}
+
+// Once is like Times(1)
+func (s *Stub) Once() *Stub {
+	return s.Times(1)
+}
+
+func (s *Stub) matches(op ibclient.RequestType, objType string, ref string) bool {
+	return s.op == op &&
+		(s.objType == "" || s.objType == objType) &&
+		(s.ref == "" || s.ref == ref) &&
+		(s.times == 0 || s.calls < s.times)
+}
+
+// FakeConnector is a programmable IBConnector recording its calls, for the
+// unit tests of code built on ObjectManager.
+//
+// Calls matching no stub succeed: searches return no results, reads by
+// reference fail with ibclient.ErrNotFound and other calls return a fake
+// reference.
+//
+//	conn := ibclienttest.NewFakeConnector()
+//	conn.On(ibclient.GET, "network", "").Return([]ibclient.Network{{Ref: ref, Cidr: "10.0.0.0/24"}})
+//	conn.On(ibclient.DELETE, "", ref).ReturnError(ibclient.ErrPermissionDenied)
+//	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+//	...
+//	conn.AssertCalled(t, ibclient.DELETE, "network", ref)
+type FakeConnector struct {
+	mu    sync.Mutex
+	stubs []*Stub
+	calls []Call
+	seq   int
+}
+
+var (
+	_ ibclient.IBConnector        = &FakeConnector{}
+	_ ibclient.MultiObjectCreator = &FakeConnector{}
+	_ ibclient.FunctionCaller     = &FakeConnector{}
+)
+
+// NewFakeConnector returns a FakeConnector without stubs
+func NewFakeConnector() *FakeConnector {
+	return &FakeConnector{}
+}
+
+// On adds a stub for the calls of op on objects of type objType, and with
+// reference ref. An empty objType or ref matches any. Stubs are tried in the
+// order they were added. MultiRequest calls have the "request" object type,
+// function calls are CREATE calls on the referenced object.
+func (f *FakeConnector) On(op ibclient.RequestType, objType string, ref string) *Stub {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s := &Stub{op: op, objType: objType, ref: ref}
+	f.stubs = append(f.stubs, s)
+	return s
+}
+
+// Reset removes the stubs and recorded calls
+func (f *FakeConnector) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stubs = nil
+	f.calls = nil
+}
+
+// record saves the call and returns the first matching stub, or nil
+func (f *FakeConnector) record(c Call) *Stub {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, c)
+	for _, s := range f.stubs {
+		if s.matches(c.Op, c.ObjectType, c.Ref) {
+			s.calls++
+			return s
+		}
+	}
+	return nil
+}
+
+func (f *FakeConnector) fakeRef(objType string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	return fmt.Sprintf("%s/ZmFrZQ%d:fake", objType, f.seq)
+}
+
+func newCall(op ibclient.RequestType, obj ibclient.IBObject, ref string) Call {
+	c := Call{Op: op, Object: obj, Ref: ref}
+	if obj != nil {
+		c.ObjectType = obj.ObjectType()
+		c.ReturnFields = obj.ReturnFields()
+		if q := obj.Query(); q != nil {
+			c.Query = q.Values()
+		}
+	} else {
+		c.ObjectType = objectTypeOf(ref)
+	}
+	return c
+}
+
+// objectTypeOf returns the object type of a reference
+func objectTypeOf(ref string) string {
+	if i := strings.Index(ref, "/"); i >= 0 {
+		return ref[:i]
+	}
+	return ""
+}
+
+// Calls returns the calls received so far
+func (f *FakeConnector) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls of op on objects of type objType, any type if
+// empty
+func (f *FakeConnector) CallsTo(op ibclient.RequestType, objType string) []Call {
+	var res []Call
+	for _, c := range f.Calls() {
+		if c.Op == op && (objType == "" || c.ObjectType == objType) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func (f *FakeConnector) called(op ibclient.RequestType, objType string, ref string) bool {
+	for _, c := range f.CallsTo(op, objType) {
+		if ref == "" || c.Ref == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertCalled fails the test unless op was called on objType with ref. An
+// empty objType or ref matches any.
+func (f *FakeConnector) AssertCalled(t testing.TB, op ibclient.RequestType, objType string, ref string) {
+	t.Helper()
+	if !f.called(op, objType, ref) {
+		t.Errorf("expected a call of %s on %q with ref %q, got %s", opName(op), objType, ref, f.describeCalls())
+	}
+}
+
+// AssertNotCalled fails the test if op was called on objType with ref. An
+// empty objType or ref matches any.
+func (f *FakeConnector) AssertNotCalled(t testing.TB, op ibclient.RequestType, objType string, ref string) {
+	t.Helper()
+	if f.called(op, objType, ref) {
+		t.Errorf("unexpected call of %s on %q with ref %q, got %s", opName(op), objType, ref, f.describeCalls())
+	}
+}
+
+// AssertNumberOfCalls fails the test unless op was called n times on
+// objType, any type if empty
+func (f *FakeConnector) AssertNumberOfCalls(t testing.TB, op ibclient.RequestType, objType string, n int) {
+	t.Helper()
+	if got := len(f.CallsTo(op, objType)); got != n {
+		t.Errorf("expected %d calls of %s on %q, got %d", n, opName(op), objType, got)
+	}
+}
+
+// AssertStubsUsed fails the test if a stub was never matched, or matched
+// fewer times than set with Times
+func (f *FakeConnector) AssertStubsUsed(t testing.TB) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.stubs {
+		if s.calls == 0 || s.calls < s.times {
+			t.Errorf("stub of %s on %q with ref %q was called %d times", opName(s.op), s.objType, s.ref, s.calls)
+		}
+	}
+}
+
+func (f *FakeConnector) describeCalls() string {
+	calls := f.Calls()
+	if len(calls) == 0 {
+		return "no calls"
+	}
+	var parts []string
+	for _, c := range calls {
+		parts = append(parts, fmt.Sprintf("%s %s %s", opName(c.Op), c.ObjectType, c.Ref))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func opName(op ibclient.RequestType) string {
+	switch op {
+	case ibclient.CREATE:
+		return "CREATE"
+	case ibclient.GET:
+		return "GET"
+	case ibclient.DELETE:
+		return "DELETE"
+	case ibclient.UPDATE:
+		return "UPDATE"
+	}
+	return fmt.Sprintf("RequestType(%d)", op)
+}
+
+// refResult returns the reference scripted by s, or a fake one
+func (f *FakeConnector) refResult(s *Stub, c Call) (string, error) {
+	if s != nil {
+		if s.err != nil {
+			return "", s.err
+		}
+		if ref, ok := s.result.(string); ok {
+			return ref, nil
+		}
+	}
+	if c.Ref != "" {
+		return c.Ref, nil
+	}
+	return f.fakeRef(c.ObjectType), nil
+}
+
+// setResult copies the result of a stub into res
+func setResult(result interface{}, res interface{}) error {
+	dst := reflect.ValueOf(res)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("result argument must be a non-nil pointer, got %T", res)
+	}
+
+	src := reflect.ValueOf(result)
+	if src.Kind() == reflect.Ptr && !src.IsNil() && src.Elem().Type().AssignableTo(dst.Elem().Type()) {
+		src = src.Elem()
+	}
+	if src.IsValid() && src.Type().AssignableTo(dst.Elem().Type()) {
+		dst.Elem().Set(src)
+		return nil
+	}
+
+	b, ok := result.([]byte)
+	if !ok {
+		var err error
+		if b, err = json.Marshal(result); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, res)
+}
+
+// CreateObject implements ibclient.IBConnector
+func (f *FakeConnector) CreateObject(obj ibclient.IBObject) (string, error) {
+	return f.CreateObjectWithContext(context.Background(), obj)
+}
+
+// CreateObjectWithContext implements ibclient.IBConnector
+func (f *FakeConnector) CreateObjectWithContext(ctx context.Context, obj ibclient.IBObject) (string, error) {
+	c := newCall(ibclient.CREATE, obj, "")
+	return f.refResult(f.record(c), c)
+}
+
+// GetObject implements ibclient.IBConnector
+func (f *FakeConnector) GetObject(obj ibclient.IBObject, ref string, res interface{}) error {
+	return f.GetObjectWithContext(context.Background(), obj, ref, res)
+}
+
+// GetObjectWithContext implements ibclient.IBConnector
+func (f *FakeConnector) GetObjectWithContext(ctx context.Context, obj ibclient.IBObject, ref string, res interface{}) error {
+	c := newCall(ibclient.GET, obj, ref)
+	_, err := f.get(c, res)
+	return err
+}
+
+// GetObjectPage implements ibclient.IBConnector
+func (f *FakeConnector) GetObjectPage(obj ibclient.IBObject, pageID string, maxResults int, res interface{}) (string, error) {
+	return f.GetObjectPageWithContext(context.Background(), obj, pageID, maxResults, res)
+}
+
+// GetObjectPageWithContext implements ibclient.IBConnector
+func (f *FakeConnector) GetObjectPageWithContext(ctx context.Context, obj ibclient.IBObject, pageID string, maxResults int, res interface{}) (string, error) {
+	c := newCall(ibclient.GET, obj, "")
+	c.PageID = pageID
+	c.MaxResults = maxResults
+	return f.get(c, res)
+}
+
+func (f *FakeConnector) get(c Call, res interface{}) (string, error) {
+	s := f.record(c)
+	switch {
+	case s == nil && c.Ref != "":
+		return "", fmt.Errorf("%w: fake connector has no object %s", ibclient.ErrNotFound, c.Ref)
+	case s == nil:
+		return "", nil
+	case s.err != nil:
+		return "", s.err
+	case s.result != nil:
+		if err := setResult(s.result, res); err != nil {
+			return "", err
+		}
+	}
+	return s.nextPageID, nil
+}
+
+// DeleteObject implements ibclient.IBConnector
+func (f *FakeConnector) DeleteObject(ref string) (string, error) {
+	return f.DeleteObjectWithContext(context.Background(), ref)
+}
+
+// DeleteObjectWithContext implements ibclient.IBConnector
+func (f *FakeConnector) DeleteObjectWithContext(ctx context.Context, ref string) (string, error) {
+	c := newCall(ibclient.DELETE, nil, ref)
+	return f.refResult(f.record(c), c)
+}
+
+// UpdateObject implements ibclient.IBConnector
+func (f *FakeConnector) UpdateObject(obj ibclient.IBObject, ref string) (string, error) {
+	return f.UpdateObjectWithContext(context.Background(), obj, ref)
+}
+
+// UpdateObjectWithContext implements ibclient.IBConnector
+func (f *FakeConnector) UpdateObjectWithContext(ctx context.Context, obj ibclient.IBObject, ref string) (string, error) {
+	c := newCall(ibclient.UPDATE, obj, ref)
+	return f.refResult(f.record(c), c)
+}
+
+// CreateMultiObjectWithContext implements ibclient.MultiObjectCreator, the
+// MultiRequest is recorded as a CREATE call on the "request" object type
+func (f *FakeConnector) CreateMultiObjectWithContext(ctx context.Context, req *ibclient.MultiRequest) ([]map[string]interface{}, error) {
+	s := f.record(newCall(ibclient.CREATE, req, ""))
+	var res []map[string]interface{}
+	if s == nil {
+		return res, nil
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.result != nil {
+		if err := setResult(s.result, &res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// CallFunctionWithContext implements ibclient.FunctionCaller. Calls without
+// a stub return an empty result.
+func (f *FakeConnector) CallFunctionWithContext(ctx context.Context, ref string, function string, args ibclient.IBObject, res interface{}) error {
+	c := newCall(ibclient.CREATE, args, ref)
+	c.ObjectType = objectTypeOf(ref)
+	c.Function = function
+	s := f.record(c)
+	switch {
+	case s == nil:
+		return nil
+	case s.err != nil:
+		return s.err
+	case s.result != nil:
+		return setResult(s.result, res)
+	}
+	return nil
+}
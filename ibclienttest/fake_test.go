@@ -0,0 +1,120 @@
+package ibclienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ibclient "jimrazmus/infoblox-go-client"
+)
+
+func TestFakeConnectorScriptedResponses(t *testing.T) {
+	conn := NewFakeConnector()
+	ref := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3MkMTAuMC4wLjEuMC4u:10.0.0.1/default"
+	conn.On(ibclient.GET, "fixedaddress", "").Return([]ibclient.FixedAddress{{Ref: ref, IPAddress: "10.0.0.1"}}).Once()
+	conn.On(ibclient.DELETE, "", ref).ReturnError(ibclient.ErrPermissionDenied)
+	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+
+	if _, err := objMgr.ReleaseIP("default", "10.0.0.0/24", "10.0.0.1", ""); !errors.Is(err, ibclient.ErrPermissionDenied) {
+		t.Errorf("expected a permission error, got %v", err)
+	}
+	// the GET stub is used up, nothing is released anymore
+	if res, err := objMgr.ReleaseIP("default", "10.0.0.0/24", "10.0.0.1", ""); res != "" || err != nil {
+		t.Errorf("unexpected result %q, error %v", res, err)
+	}
+
+	conn.AssertCalled(t, ibclient.DELETE, "fixedaddress", ref)
+	conn.AssertNumberOfCalls(t, ibclient.GET, "fixedaddress", 2)
+	conn.AssertNumberOfCalls(t, ibclient.DELETE, "", 1)
+	conn.AssertNotCalled(t, ibclient.CREATE, "", "")
+	conn.AssertStubsUsed(t)
+
+	get := conn.CallsTo(ibclient.GET, "fixedaddress")[0]
+	if fa := get.Object.(*ibclient.FixedAddress); fa.IPAddress != "10.0.0.1" || fa.NetviewName != "default" {
+		t.Errorf("unexpected searched object %+v", fa)
+	}
+}
+
+func TestFakeConnectorDefaults(t *testing.T) {
+	conn := NewFakeConnector()
+	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+
+	network, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web")
+	if err != nil || network.Ref != "network/ZmFrZQ1:fake" {
+		t.Errorf("unexpected network %+v, error %v", network, err)
+	}
+	if network, err := objMgr.GetNetwork("default", "10.0.0.0/24", nil); network != nil || err != nil {
+		t.Errorf("unexpected network %+v, error %v", network, err)
+	}
+	if _, err := objMgr.GetFixedAddressByRef("fixedaddress/xyz:10.0.0.1/default"); !errors.Is(err, ibclient.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestFakeConnectorQueryAndPaging(t *testing.T) {
+	conn := NewFakeConnector()
+	conn.On(ibclient.GET, "network", "").ReturnPage([]byte(`[{"network": "10.0.0.0/24"}]`), "page2").Once()
+	conn.On(ibclient.GET, "network", "").ReturnPage([]map[string]interface{}{{"network": "10.0.1.0/24"}}, "")
+
+	var networks []ibclient.Network
+	obj := ibclient.NewNetwork(ibclient.Network{})
+	obj.SetQuery(ibclient.NewQuery().Regex("network", "^10"))
+	if err := ibclient.GetAllObjects(conn, obj, 1, &networks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(networks) != 2 || networks[0].Cidr != "10.0.0.0/24" || networks[1].Cidr != "10.0.1.0/24" {
+		t.Errorf("unexpected networks %+v", networks)
+	}
+
+	calls := conn.Calls()
+	if len(calls) != 2 || calls[1].PageID != "page2" || calls[0].MaxResults != 1 || calls[0].Query.Get("network~") != "^10" {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+}
+
+func TestFakeConnectorMultiRequest(t *testing.T) {
+	conn := NewFakeConnector()
+	conn.On(ibclient.CREATE, "request", "").Return([]map[string]interface{}{{"ipv4addr": "10.0.0.1"}})
+	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+
+	res, err := objMgr.CreateMultiObject(ibclient.NewMultiRequest(nil))
+	if err != nil || len(res) != 1 || res[0]["ipv4addr"] != "10.0.0.1" {
+		t.Errorf("unexpected result %v, error %v", res, err)
+	}
+	conn.AssertCalled(t, ibclient.CREATE, "request", "")
+}
+
+func TestFakeConnectorFunctionCall(t *testing.T) {
+	conn := NewFakeConnector()
+	ref := "network/ZG5z:10.0.0.0/24/default"
+	conn.On(ibclient.CREATE, "network", ref).Return(map[string]interface{}{"ips": []string{"10.0.0.5", "10.0.0.6"}})
+	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+
+	ips, err := objMgr.GetNextAvailableIPs(ref, 2, nil)
+	if err != nil || len(ips) != 2 || ips[0] != "10.0.0.5" {
+		t.Errorf("unexpected addresses %v, error %v", ips, err)
+	}
+	calls := conn.CallsTo(ibclient.CREATE, "network")
+	if len(calls) != 1 || calls[0].Function != "next_available_ip" || calls[0].Ref != ref {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+}
+
+func TestFakeConnectorNetworkViewLockUnstubbed(t *testing.T) {
+	conn := NewFakeConnector()
+	conn.On(ibclient.GET, "networkview", "").Return([]ibclient.NetworkView{
+		{Ref: "networkview/ZG5z:default/true", Name: "default", Ea: ibclient.EA{"Lock": "Available"}}})
+	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+	l := &ibclient.NetworkViewLock{Name: "default", ObjMgr: objMgr, LockEA: "Lock", LockTimeoutEA: "LockTime"}
+
+	// the unstubbed multiple object requests return no lock state
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.LockWithContext(ctx); err == nil {
+		t.Error("expected an error locking without a lock state")
+	}
+	if err := l.UnLock(false); err == nil {
+		t.Error("expected an error unlocking without a lock state")
+	}
+}
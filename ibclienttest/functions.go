@@ -0,0 +1,416 @@
+package ibclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// evalFunctions replaces the func:nextavailableip and
+// func:nextavailablenetwork values of fields, including those of
+// sub-objects such as host addresses, by the allocated values
+func (s *Simulator) evalFunctions(fields map[string]interface{}) error {
+	reserved := map[string]bool{}
+	eval := func(m map[string]interface{}) error {
+		for k, v := range m {
+			str, ok := v.(string)
+			if !ok || !strings.HasPrefix(str, "func:") {
+				continue
+			}
+			res, err := s.evalFunction(str, reserved)
+			if err != nil {
+				return err
+			}
+			m[k] = res
+		}
+		return nil
+	}
+
+	if err := eval(fields); err != nil {
+		return err
+	}
+	for _, v := range fields {
+		list, _ := v.([]interface{})
+		for _, e := range list {
+			if m, ok := e.(map[string]interface{}); ok {
+				if err := eval(m); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) evalFunction(call string, reserved map[string]bool) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(call, "func:"), ":", 2)
+	if len(parts) != 2 {
+		return "", protoError("Invalid function call " + call)
+	}
+	args := strings.Split(parts[1], ",")
+
+	switch parts[0] {
+	case "nextavailableip":
+		network, err := s.functionNetwork(args, "network")
+		if err != nil {
+			return "", err
+		}
+		ip, err := s.nextAvailableIP(network, reserved)
+		if err != nil {
+			return "", err
+		}
+		reserved[ip] = true
+		return ip, nil
+	case "nextavailablenetwork":
+		if len(args) < 2 {
+			return "", protoError("Invalid arguments for nextavailablenetwork: " + parts[1])
+		}
+		prefixLen, err := strconv.Atoi(args[len(args)-1])
+		if err != nil {
+			return "", protoError("Invalid prefix length " + args[len(args)-1])
+		}
+		container, err := s.functionNetwork(args[:len(args)-1], "networkcontainer")
+		if err != nil {
+			return "", err
+		}
+		return s.nextAvailableNetwork(container, prefixLen, nil)
+	}
+
+	return "", protoError("Unknown function " + parts[0])
+}
+
+// callFunction executes the function called with the _function argument
+// on the object with reference ref, next_available_ip on networks and
+// ranges or next_available_network on network containers and networks
+func (s *Simulator) callFunction(ref string, function string, body []byte) (interface{}, error) {
+	obj := s.lookup(ref)
+	if obj == nil {
+		return nil, notFoundError(ref)
+	}
+
+	var args struct {
+		Num     int      `json:"num"`
+		Exclude []string `json:"exclude"`
+		Cidr    int      `json:"cidr"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &args); err != nil {
+			return nil, protoError("Invalid arguments for " + function + ": " + err.Error())
+		}
+	}
+	if args.Num <= 0 {
+		args.Num = 1
+	}
+
+	switch {
+	case function == "next_available_ip" && (isNetworkType(obj.objType) || obj.objType == "range") &&
+		!strings.HasSuffix(obj.objType, "container"):
+		reserved := map[string]bool{}
+		for _, ip := range args.Exclude {
+			reserved[formatValue(canonicalIP(ip))] = true
+		}
+		ips := []interface{}{}
+		for len(ips) < args.Num {
+			ip, err := s.nextAvailableIP(obj, reserved)
+			if err != nil {
+				return nil, conflictError(fmt.Sprintf("Cannot find %d available IP address(es) in this network", args.Num))
+			}
+			reserved[ip] = true
+			ips = append(ips, ip)
+		}
+		return map[string]interface{}{"ips": ips}, nil
+	case function == "next_available_network" && isNetworkType(obj.objType):
+		if args.Cidr == 0 {
+			return nil, protoError("Field cidr is required for next_available_network")
+		}
+		exclude := args.Exclude
+		networks := []interface{}{}
+		for len(networks) < args.Num {
+			cidr, err := s.nextAvailableNetwork(obj, args.Cidr, exclude)
+			if err != nil {
+				if wapiErr, ok := err.(*wapiError); ok && wapiErr.Code == "Client.Ibap.Data.Conflict" {
+					return nil, conflictError(fmt.Sprintf("Cannot find %d available network(s) in container %s",
+						args.Num, formatValue(obj.fields["network"])))
+				}
+				return nil, err
+			}
+			exclude = append(exclude, cidr)
+			networks = append(networks, cidr)
+		}
+		return map[string]interface{}{"networks": networks}, nil
+	}
+
+	return nil, protoError(fmt.Sprintf("Function %s is not valid for object type %s", function, obj.objType))
+}
+
+// functionNetwork returns the network or container designated by the
+// arguments of a function, either a reference or a CIDR and network view
+func (s *Simulator) functionNetwork(args []string, objType string) (*object, error) {
+	if len(args) == 0 || args[0] == "" {
+		return nil, protoError("Missing network argument")
+	}
+	if isFunctionRef(args[0]) {
+		obj := s.lookup(args[0])
+		if obj == nil {
+			return nil, notFoundError(args[0])
+		}
+		return obj, nil
+	}
+
+	cidr, err := canonicalCIDR(args[0])
+	if err != nil {
+		return nil, dataError(fmt.Sprintf("None (IBDataError: IB.Data:Invalid network %s)", args[0]))
+	}
+	if strings.Contains(cidr, ":") {
+		objType = "ipv6" + objType
+	}
+	netview := "default"
+	if len(args) > 1 && args[1] != "" {
+		netview = args[1]
+	}
+
+	for _, obj := range s.list(objType) {
+		if obj.fields["network"] == cidr && obj.fields["network_view"] == netview {
+			return obj, nil
+		}
+	}
+	return nil, dataError(fmt.Sprintf("None (IBDataNotFoundError: IB.Data.NotFound:No %s %s found in network view %s)", objType, cidr, netview))
+}
+
+// isFunctionRef tells references apart from CIDRs, including IPv6 ones
+// which contain colons before the slash
+func isFunctionRef(arg string) bool {
+	slash, colon := strings.Index(arg, "/"), strings.Index(arg, ":")
+	return slash > 0 && colon > slash
+}
+
+// nextAvailableIP returns the first address of network, or of a range,
+// which is neither used by an object of its network view nor reserved
+func (s *Simulator) nextAvailableIP(network *object, reserved map[string]bool) (string, error) {
+	used := s.usedAddresses(formatValue(network.fields["network_view"]))
+
+	var first, last *big.Int
+	var bits int
+	if network.objType == "range" {
+		start, end := net.ParseIP(formatValue(network.fields["start_addr"])), net.ParseIP(formatValue(network.fields["end_addr"]))
+		if start == nil || end == nil {
+			return "", dataError("None (IBDataError: IB.Data:Invalid range)")
+		}
+		bits = 32
+		if start.To4() == nil {
+			bits = 128
+		} else {
+			start, end = start.To4(), end.To4()
+		}
+		first, last = new(big.Int).SetBytes(start), new(big.Int).SetBytes(end)
+	} else {
+		_, ipNet, err := net.ParseCIDR(formatValue(network.fields["network"]))
+		if err != nil {
+			return "", err
+		}
+		first, last = addressRange(ipNet)
+		var ones int
+		ones, bits = ipNet.Mask.Size()
+		// the network and broadcast addresses of IPv4 networks are not usable
+		if bits == 32 && ones < 31 {
+			first.Add(first, big.NewInt(1))
+			last.Sub(last, big.NewInt(1))
+		}
+		// neither is the subnet-router anycast address of IPv6 networks
+		if bits == 128 && ones < 127 {
+			first.Add(first, big.NewInt(1))
+		}
+	}
+
+	for i := first; i.Cmp(last) <= 0; i.Add(i, big.NewInt(1)) {
+		ip := intToIP(i, bits).String()
+		if !used[ip] && !reserved[ip] {
+			return ip, nil
+		}
+	}
+	return "", conflictError("Cannot find 1 available IP address(es) in this network")
+}
+
+// usedAddresses returns the addresses of the objects of a network view.
+// DNS records are not bound to a network view and are always counted.
+func (s *Simulator) usedAddresses(netview string) map[string]bool {
+	used := map[string]bool{}
+	add := func(m map[string]interface{}) {
+		for _, f := range []string{"ipv4addr", "ipv6addr"} {
+			if ip := net.ParseIP(formatValue(m[f])); ip != nil {
+				used[ip.String()] = true
+			}
+		}
+	}
+
+	for _, obj := range s.objects {
+		if nv, ok := obj.fields["network_view"]; ok && nv != netview {
+			continue
+		}
+		add(obj.fields)
+		for _, v := range obj.fields {
+			list, _ := v.([]interface{})
+			for _, e := range list {
+				if m, ok := e.(map[string]interface{}); ok {
+					add(m)
+				}
+			}
+		}
+	}
+	return used
+}
+
+// nextAvailableNetwork returns the first network of prefixLen bits in
+// container which doesn't overlap another network of its network view nor
+// one of the excluded networks
+func (s *Simulator) nextAvailableNetwork(container *object, prefixLen int, exclude []string) (string, error) {
+	cidr := formatValue(container.fields["network"])
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := ipNet.Mask.Size()
+	if prefixLen < ones || prefixLen > bits {
+		return "", dataError(fmt.Sprintf("None (IBDataError: IB.Data:Invalid prefix length %d for container %s)", prefixLen, cidr))
+	}
+
+	type span struct{ first, last *big.Int }
+	var taken []span
+	netview := container.fields["network_view"]
+	containerFirst, containerLast := addressRange(ipNet)
+	for _, obj := range s.objects {
+		if obj.fields["network_view"] != netview || !isNetworkType(obj.objType) {
+			continue
+		}
+		_, n, err := net.ParseCIDR(formatValue(obj.fields["network"]))
+		if err != nil {
+			continue
+		}
+		first, last := addressRange(n)
+		// the container and its parents don't take any space
+		if first.Cmp(containerFirst) <= 0 && last.Cmp(containerLast) >= 0 {
+			continue
+		}
+		taken = append(taken, span{first, last})
+	}
+	for _, cidr := range exclude {
+		if _, n, err := net.ParseCIDR(cidr); err == nil {
+			first, last := addressRange(n)
+			taken = append(taken, span{first, last})
+		}
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLen))
+	candidate := new(big.Int).Set(containerFirst)
+	for {
+		candidateLast := new(big.Int).Add(candidate, size)
+		candidateLast.Sub(candidateLast, big.NewInt(1))
+		if candidateLast.Cmp(containerLast) > 0 {
+			break
+		}
+
+		// skip past the overlapping networks, aligned on the prefix
+		var next *big.Int
+		for _, t := range taken {
+			if t.first.Cmp(candidateLast) <= 0 && t.last.Cmp(candidate) >= 0 {
+				if next == nil || t.last.Cmp(next) > 0 {
+					next = t.last
+				}
+			}
+		}
+		if next == nil {
+			return fmt.Sprintf("%s/%d", intToIP(candidate, bits), prefixLen), nil
+		}
+
+		candidate = new(big.Int).Add(next, big.NewInt(1))
+		if rem := new(big.Int).Mod(candidate, size); rem.Sign() != 0 {
+			candidate.Add(candidate, new(big.Int).Sub(size, rem))
+		}
+	}
+
+	return "", conflictError(fmt.Sprintf("Cannot find 1 available network(s) in container %s", cidr))
+}
+
+func isNetworkType(objType string) bool {
+	switch objType {
+	case "network", "networkcontainer", "ipv6network", "ipv6networkcontainer":
+		return true
+	}
+	return false
+}
+
+// containingNetwork returns the network or IPv6 network of netview holding
+// ip
+func (s *Simulator) containingNetwork(ip string, netview string) *object {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil
+	}
+	objType := "network"
+	if addr.To4() == nil {
+		objType = "ipv6network"
+	}
+	for _, obj := range s.list(objType) {
+		if obj.fields["network_view"] != netview {
+			continue
+		}
+		if _, n, err := net.ParseCIDR(formatValue(obj.fields["network"])); err == nil && n.Contains(addr) {
+			return obj
+		}
+	}
+	return nil
+}
+
+func canonicalCIDR(cidr string) (string, error) {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// addressRange returns the first and last addresses of n as integers
+func addressRange(n *net.IPNet) (*big.Int, *big.Int) {
+	ip := n.IP
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	first := new(big.Int).SetBytes(ip)
+	ones, bits := n.Mask.Size()
+	last := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	last.Sub(last, big.NewInt(1))
+	last.Add(last, first)
+	return first, last
+}
+
+func intToIP(i *big.Int, bits int) net.IP {
+	b := i.Bytes()
+	ip := make(net.IP, bits/8)
+	copy(ip[len(ip)-len(b):], b)
+	return ip
+}
+
+// reverseName returns the in-addr.arpa or ip6.arpa name of ip
+func reverseName(ip string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ""
+	}
+	if v4 := addr.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", v4[3], v4[2], v4[1], v4[0])
+	}
+
+	const hexDigits = "0123456789abcdef"
+	var sb strings.Builder
+	for i := len(addr) - 1; i >= 0; i-- {
+		sb.WriteByte(hexDigits[addr[i]&0xf])
+		sb.WriteByte('.')
+		sb.WriteByte(hexDigits[addr[i]>>4])
+		sb.WriteByte('.')
+	}
+	sb.WriteString("ip6.arpa")
+	return sb.String()
+}
@@ -0,0 +1,135 @@
+package ibclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// multiRequestItem is a request of the body of the request endpoint
+type multiRequestItem struct {
+	Method             string                 `json:"method"`
+	Object             string                 `json:"object"`
+	Data               map[string]interface{} `json:"data"`
+	Args               map[string]string      `json:"args"`
+	EnableSubstitution bool                   `json:"enable_substitution"`
+	AssignState        map[string]string      `json:"assign_state"`
+	Discard            bool                   `json:"discard"`
+}
+
+var stateRegexp = regexp.MustCompile(`##STATE:([^:]+):##`)
+
+// multiRequest executes the requests of the request endpoint in order, as
+// a single transaction: the objects are restored if one of them fails
+func (s *Simulator) multiRequest(body []byte) (interface{}, error) {
+	var items []multiRequestItem
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, protoError("Cannot parse the JSON request body: " + err.Error())
+	}
+
+	saved := s.snapshot()
+	state := map[string]interface{}{}
+	results := []interface{}{}
+	for i, item := range items {
+		res, err := s.multiRequestItem(item, state)
+		if err != nil {
+			s.objects = saved
+			if wapiErr, ok := err.(*wapiError); ok {
+				wapiErr.Text = fmt.Sprintf("%s (request #%d)", wapiErr.Text, i+1)
+			}
+			return nil, err
+		}
+
+		for name, field := range item.AssignState {
+			state[name] = resultField(res, field)
+		}
+		if !item.Discard {
+			if ref, ok := res.(string); ok {
+				res = map[string]interface{}{"_ref": ref}
+			}
+			results = append(results, res)
+		}
+	}
+
+	return results, nil
+}
+
+func (s *Simulator) multiRequestItem(item multiRequestItem, state map[string]interface{}) (interface{}, error) {
+	if item.EnableSubstitution {
+		item.Object = substitute(item.Object, state).(string)
+		item.Data, _ = substitute(item.Data, state).(map[string]interface{})
+	}
+	// the state requests don't address an object, STATE:ASSIGN adds its data
+	// to the state and both return the state
+	switch strings.ToUpper(item.Method) {
+	case "STATE:ASSIGN":
+		for k, v := range item.Data {
+			state[k] = v
+		}
+		fallthrough
+	case "STATE:DISPLAY":
+		return deepCopy(state), nil
+	}
+	if item.Object == "" || item.Object == "request" {
+		return nil, protoError("Invalid object for the request object: " + item.Object)
+	}
+
+	args := url.Values{}
+	for k, v := range item.Args {
+		args.Set(k, v)
+	}
+	var data []byte
+	if item.Data != nil {
+		var err error
+		if data, err = json.Marshal(item.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.dispatch(strings.ToUpper(item.Method), item.Object, args, data)
+}
+
+// substitute replaces the ##STATE:name:## markers of the strings of v by
+// the values assigned to the state
+func substitute(v interface{}, state map[string]interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return stateRegexp.ReplaceAllStringFunc(val, func(m string) string {
+			return formatValue(state[stateRegexp.FindStringSubmatch(m)[1]])
+		})
+	case map[string]interface{}:
+		for k, e := range val {
+			val[k] = substitute(e, state)
+		}
+	case []interface{}:
+		for i, e := range val {
+			val[i] = substitute(e, state)
+		}
+	}
+	return v
+}
+
+// resultField returns a field of the result of a request, of the first
+// object found for searches. Extensible attributes are prefixed with *.
+func resultField(res interface{}, field string) interface{} {
+	switch val := res.(type) {
+	case string:
+		if field == "_ref" {
+			return val
+		}
+	case map[string]interface{}:
+		if strings.HasPrefix(field, "*") {
+			eas, _ := val["extattrs"].(map[string]interface{})
+			ea, _ := eas[field[1:]].(map[string]interface{})
+			return ea["value"]
+		}
+		return val[field]
+	case []interface{}:
+		if len(val) > 0 {
+			return resultField(val[0], field)
+		}
+	}
+	return nil
+}
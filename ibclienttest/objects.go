@@ -0,0 +1,700 @@
+package ibclienttest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// object is an object stored by the Simulator
+type object struct {
+	objType string
+	id      string
+	seq     int
+	ref     string
+	fields  map[string]interface{}
+}
+
+// objectKind describes how the Simulator handles an object type
+type objectKind struct {
+	// refName returns the part of the reference after the id
+	refName func(fields map[string]interface{}) string
+	// defaultFields are returned when no _return_fields are requested
+	defaultFields []string
+	// unique are the fields whose values identify an object
+	unique []string
+	// defaults are set on new objects
+	defaults map[string]interface{}
+	// readOnly are the fields computed by the Grid
+	readOnly []string
+}
+
+func refName(fields ...string) func(map[string]interface{}) string {
+	return func(m map[string]interface{}) string {
+		var parts []string
+		for _, f := range fields {
+			parts = append(parts, formatValue(m[f]))
+		}
+		return strings.Join(parts, "/")
+	}
+}
+
+func recordKind(defaultFields []string, unique ...string) objectKind {
+	return objectKind{
+		refName:       refName("name", "view"),
+		defaultFields: defaultFields,
+		unique:        append(unique, "view"),
+		defaults:      map[string]interface{}{"view": "default"},
+		readOnly:      []string{"zone"},
+	}
+}
+
+// objectKinds are the object types known by the Simulator
+var objectKinds = map[string]objectKind{
+	"networkview": {
+		refName:       refName("name", "is_default"),
+		defaultFields: []string{"comment", "is_default", "name"},
+		unique:        []string{"name"},
+		defaults:      map[string]interface{}{"is_default": false},
+	},
+	"view": {
+		refName:       refName("name", "is_default"),
+		defaultFields: []string{"comment", "is_default", "name"},
+		unique:        []string{"name"},
+		defaults:      map[string]interface{}{"is_default": false, "network_view": "default"},
+	},
+	"userprofile": {
+		refName:       refName("name"),
+		defaultFields: []string{"name"},
+		unique:        []string{"name"},
+	},
+	"extensibleattributedef": {
+		refName:       refName("name"),
+		defaultFields: []string{"comment", "default_value", "name", "type"},
+		unique:        []string{"name"},
+	},
+	"network": {
+		refName:       refName("network", "network_view"),
+		defaultFields: []string{"comment", "network", "network_view"},
+		unique:        []string{"network", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default"},
+	},
+	"networkcontainer": {
+		refName:       refName("network", "network_view"),
+		defaultFields: []string{"comment", "network", "network_view"},
+		unique:        []string{"network", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default"},
+	},
+	"ipv6network": {
+		refName:       refName("network", "network_view"),
+		defaultFields: []string{"comment", "network", "network_view"},
+		unique:        []string{"network", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default"},
+	},
+	"ipv6networkcontainer": {
+		refName:       refName("network", "network_view"),
+		defaultFields: []string{"comment", "network", "network_view"},
+		unique:        []string{"network", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default"},
+	},
+	"ipv6fixedaddress": {
+		refName:       refName("ipv6addr", "network_view"),
+		defaultFields: []string{"duid", "ipv6addr", "network_view"},
+		unique:        []string{"ipv6addr", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default"},
+	},
+	"fixedaddress": {
+		refName:       refName("ipv4addr", "network_view"),
+		defaultFields: []string{"ipv4addr", "network_view"},
+		unique:        []string{"ipv4addr", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default"},
+	},
+	"dhcpoptionspace": {
+		refName:       refName("name"),
+		defaultFields: []string{"comment", "name"},
+		unique:        []string{"name"},
+	},
+	"dhcpoptiondefinition": {
+		refName:       refName("name", "space"),
+		defaultFields: []string{"code", "name", "type"},
+		unique:        []string{"space", "code"},
+		defaults:      map[string]interface{}{"space": "DHCP"},
+	},
+	"lease": {
+		refName:       refName("address", "network_view"),
+		defaultFields: []string{"address", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default", "protocol": "IPV4"},
+	},
+	"range": {
+		refName:       refName("start_addr", "end_addr", "network_view"),
+		defaultFields: []string{"comment", "end_addr", "network", "network_view", "start_addr"},
+		unique:        []string{"start_addr", "end_addr", "network_view"},
+		defaults:      map[string]interface{}{"network_view": "default", "server_association_type": "NONE"},
+	},
+	"record:a":     recordKind([]string{"ipv4addr", "name", "view"}, "name", "ipv4addr"),
+	"record:aaaa":  recordKind([]string{"ipv6addr", "name", "view"}, "name", "ipv6addr"),
+	"record:ptr":   recordKind([]string{"ptrdname", "view"}, "name", "ptrdname"),
+	"record:cname": recordKind([]string{"canonical", "name", "view"}, "name"),
+	"record:txt":   recordKind([]string{"name", "text", "view"}, "name", "text"),
+	"record:host":  recordKind([]string{"ipv4addrs", "ipv6addrs", "name", "view"}, "name"),
+	"record:mx":    recordKind([]string{"mail_exchanger", "name", "preference", "view"}, "name", "mail_exchanger"),
+	"record:srv":   recordKind([]string{"name", "port", "priority", "target", "view", "weight"}, "name", "target", "port"),
+	"record:ns":    recordKind([]string{"name", "nameserver", "view"}, "name", "nameserver"),
+	"record:caa":   recordKind([]string{"ca_flag", "ca_tag", "ca_value", "name", "view"}, "name", "ca_tag", "ca_value"),
+	"zone_auth": {
+		refName:       refName("fqdn", "view"),
+		defaultFields: []string{"fqdn", "view"},
+		unique:        []string{"fqdn", "view"},
+		defaults:      map[string]interface{}{"view": "default"},
+	},
+	"zone_delegated": {
+		refName:       refName("fqdn", "view"),
+		defaultFields: []string{"delegate_to", "fqdn", "view"},
+		unique:        []string{"fqdn", "view"},
+		defaults:      map[string]interface{}{"view": "default"},
+	},
+}
+
+func unknownObjectType(objType string) error {
+	return protoError(fmt.Sprintf("Unknown object type (%s)", objType))
+}
+
+// newID returns a reference id looking like the ones of a real Grid
+func newID(objType string, seq int) string {
+	id := base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("dns.%s$%d", objType, seq)))
+	// keep ids within \w as ibclient parses references with \w+
+	return strings.NewReplacer("+", "", "/", "").Replace(id)
+}
+
+func isRef(path string) bool {
+	return strings.Contains(path, "/")
+}
+
+// lookup returns the object with the given reference, only its type and
+// id are compared as the name part changes with the object
+func (s *Simulator) lookup(ref string) *object {
+	i := strings.Index(ref, "/")
+	if i < 0 {
+		return nil
+	}
+	objType, id := ref[:i], ref[i+1:]
+	if j := strings.Index(id, ":"); j >= 0 {
+		id = id[:j]
+	}
+
+	obj := s.objects[id]
+	if obj == nil || obj.objType != objType {
+		return nil
+	}
+	return obj
+}
+
+// render returns a copy of the returnFields of the object, or of all its
+// fields if returnFields is nil
+func (obj *object) render(returnFields []string) map[string]interface{} {
+	res := map[string]interface{}{"_ref": obj.ref}
+	if returnFields == nil {
+		for k, v := range obj.fields {
+			res[k] = deepCopy(v)
+		}
+		return res
+	}
+
+	for _, f := range returnFields {
+		if v, ok := obj.fields[f]; ok {
+			res[f] = deepCopy(v)
+		} else if f == "extattrs" {
+			res[f] = map[string]interface{}{}
+		}
+	}
+	return res
+}
+
+// returnFields returns the fields requested by _return_fields and
+// _return_fields+
+func returnFields(kind objectKind, args url.Values) []string {
+	if v, ok := args["_return_fields"]; ok {
+		return splitFields(v[0])
+	}
+	if v, ok := args["_return_fields+"]; ok {
+		return append(append([]string{}, kind.defaultFields...), splitFields(v[0])...)
+	}
+	return kind.defaultFields
+}
+
+func splitFields(s string) []string {
+	res := []string{}
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+func decodeFields(body []byte) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, protoError("Cannot parse the JSON request body: " + err.Error())
+	}
+	return fields, nil
+}
+
+func checkWritable(kind objectKind, fields map[string]interface{}) error {
+	for _, f := range kind.readOnly {
+		if _, ok := fields[f]; ok {
+			return protoError("Field is not writable: " + f)
+		}
+	}
+	return nil
+}
+
+// result returns the reference of a written object, or the requested
+// fields if _return_fields was given
+func (s *Simulator) result(obj *object, args url.Values) interface{} {
+	if _, ok := args["_return_fields"]; !ok {
+		if _, ok := args["_return_fields+"]; !ok {
+			return obj.ref
+		}
+	}
+	return obj.render(returnFields(objectKinds[obj.objType], args))
+}
+
+func (s *Simulator) create(objType string, body []byte, args url.Values) (interface{}, error) {
+	kind, ok := objectKinds[objType]
+	if !ok {
+		return nil, unknownObjectType(objType)
+	}
+	fields, err := decodeFields(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritable(kind, fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "_ref")
+	for k, v := range kind.defaults {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+
+	s.seq++
+	obj := &object{objType: objType, id: newID(objType, s.seq), seq: s.seq}
+	if err := s.store(obj, fields); err != nil {
+		return nil, err
+	}
+	return s.result(obj, args), nil
+}
+
+func (s *Simulator) read(ref string, args url.Values) (interface{}, error) {
+	if host, field, i := s.lookupHostAddr(ref); host != nil {
+		return hostAddr(host, field, i).render(returnFields(hostAddrKinds["record:host_"+field], args)), nil
+	}
+	if obj := s.readIPAddress(ref); obj != nil {
+		return obj.render(returnFields(ipAddressKinds[obj.objType], args)), nil
+	}
+	obj := s.lookup(ref)
+	if obj == nil {
+		return nil, notFoundError(ref)
+	}
+	return obj.render(returnFields(objectKinds[obj.objType], args)), nil
+}
+
+func (s *Simulator) update(ref string, body []byte, args url.Values) (interface{}, error) {
+	if host, field, i := s.lookupHostAddr(ref); host != nil {
+		return s.updateHostAddr(host, field, i, body, args)
+	}
+	obj := s.lookup(ref)
+	if obj == nil {
+		return nil, notFoundError(ref)
+	}
+	fields, err := decodeFields(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritable(objectKinds[obj.objType], fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "_ref")
+
+	updated := deepCopy(obj.fields).(map[string]interface{})
+	for k, v := range fields {
+		switch {
+		case strings.HasSuffix(k, "+"):
+			k = strings.TrimSuffix(k, "+")
+			updated[k] = addValues(updated[k], v)
+		case strings.HasSuffix(k, "-"):
+			k = strings.TrimSuffix(k, "-")
+			updated[k] = removeValues(updated[k], v)
+		default:
+			updated[k] = v
+		}
+	}
+
+	if err := s.store(obj, updated); err != nil {
+		return nil, err
+	}
+	return s.result(obj, args), nil
+}
+
+// hostAddrKinds are the addresses of host records, which are stored in the
+// ipv4addrs and ipv6addrs fields of the records and can only be read and
+// updated on their own
+var hostAddrKinds = map[string]objectKind{
+	"record:host_ipv4addr": {
+		defaultFields: []string{"configure_for_dhcp", "host", "ipv4addr", "mac"},
+		readOnly:      []string{"host"},
+	},
+	"record:host_ipv6addr": {
+		defaultFields: []string{"configure_for_dhcp", "duid", "host", "ipv6addr"},
+		readOnly:      []string{"host"},
+	},
+}
+
+// lookupHostAddr returns the host record holding the address with the
+// given reference, the address field, ipv4addr or ipv6addr, and the index
+// of the address
+func (s *Simulator) lookupHostAddr(ref string) (*object, string, int) {
+	slash := strings.Index(ref, "/")
+	colon := strings.Index(ref[slash+1:], ":")
+	if slash < 0 || colon < 0 {
+		return nil, "", 0
+	}
+	objType, id := ref[:slash], ref[:slash+1+colon]
+	if _, ok := hostAddrKinds[objType]; !ok {
+		return nil, "", 0
+	}
+	field := strings.TrimPrefix(objType, "record:host_")
+
+	for _, host := range s.list("record:host") {
+		addrs, _ := host.fields[field+"s"].([]interface{})
+		for j, a := range addrs {
+			if addr, ok := a.(map[string]interface{}); ok && strings.HasPrefix(formatValue(addr["_ref"]), id+":") {
+				return host, field, j
+			}
+		}
+	}
+	return nil, "", 0
+}
+
+// hostAddr returns the address i of a host record as an object
+func hostAddr(host *object, field string, i int) *object {
+	addr := host.fields[field+"s"].([]interface{})[i].(map[string]interface{})
+	return &object{objType: "record:host_" + field, ref: formatValue(addr["_ref"]), fields: addr}
+}
+
+// hostAddrs returns the ipv4addr or ipv6addr addresses of every host record
+func (s *Simulator) hostAddrs(field string) []*object {
+	var res []*object
+	for _, host := range s.list("record:host") {
+		addrs, _ := host.fields[field+"s"].([]interface{})
+		for i := range addrs {
+			res = append(res, hostAddr(host, field, i))
+		}
+	}
+	return res
+}
+
+func (s *Simulator) updateHostAddr(host *object, field string, i int, body []byte, args url.Values) (interface{}, error) {
+	kind := hostAddrKinds["record:host_"+field]
+	fields, err := decodeFields(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritable(kind, fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "_ref")
+
+	updated := deepCopy(host.fields).(map[string]interface{})
+	addr := updated[field+"s"].([]interface{})[i].(map[string]interface{})
+	for k, v := range fields {
+		addr[k] = v
+	}
+	if err := s.store(host, updated); err != nil {
+		return nil, err
+	}
+
+	res := hostAddr(host, field, i)
+	_, all := args["_return_fields"]
+	_, more := args["_return_fields+"]
+	if !all && !more {
+		return res.ref, nil
+	}
+	return res.render(returnFields(kind, args)), nil
+}
+
+func (s *Simulator) delete(ref string) (interface{}, error) {
+	obj := s.lookup(ref)
+	if obj == nil {
+		return nil, notFoundError(ref)
+	}
+	delete(s.objects, obj.id)
+	return obj.ref, nil
+}
+
+// store evaluates the functions of fields, completes the fields computed by
+// the Grid and saves them as the new content of obj
+func (s *Simulator) store(obj *object, fields map[string]interface{}) error {
+	kind := objectKinds[obj.objType]
+
+	if err := s.evalFunctions(fields); err != nil {
+		return err
+	}
+	if err := s.complete(obj, fields); err != nil {
+		return err
+	}
+	if err := s.checkUnique(obj, kind, fields); err != nil {
+		return err
+	}
+
+	obj.fields = fields
+	obj.ref = obj.objType + "/" + obj.id + ":" + kind.refName(fields)
+	s.objects[obj.id] = obj
+	return nil
+}
+
+func (s *Simulator) checkUnique(obj *object, kind objectKind, fields map[string]interface{}) error {
+	if len(kind.unique) == 0 {
+		return nil
+	}
+
+	for _, other := range s.list(obj.objType) {
+		if other.id == obj.id {
+			continue
+		}
+		same := true
+		for _, f := range kind.unique {
+			if formatValue(other.fields[f]) != formatValue(fields[f]) {
+				same = false
+				break
+			}
+		}
+		if same {
+			var values []string
+			for _, f := range kind.unique {
+				values = append(values, formatValue(fields[f]))
+			}
+			return conflictError(fmt.Sprintf("The %s %s already exists.", obj.objType, strings.Join(values, " ")))
+		}
+	}
+	return nil
+}
+
+// complete sets the fields which a Grid computes from the others
+func (s *Simulator) complete(obj *object, fields map[string]interface{}) error {
+	switch obj.objType {
+	case "network", "networkcontainer", "ipv6network", "ipv6networkcontainer":
+		v := formatValue(fields["network"])
+		cidr, err := canonicalCIDR(v)
+		if err != nil || strings.HasPrefix(obj.objType, "ipv6") != strings.Contains(cidr, ":") {
+			return dataError(fmt.Sprintf("None (IBDataError: IB.Data:Invalid network %s)", v))
+		}
+		fields["network"] = cidr
+	case "fixedaddress", "ipv6fixedaddress":
+		field := "ipv4addr"
+		if obj.objType == "ipv6fixedaddress" {
+			field = "ipv6addr"
+			fields[field] = canonicalIP(fields[field])
+		}
+		if _, ok := fields["network"]; !ok {
+			if n := s.containingNetwork(formatValue(fields[field]), formatValue(fields["network_view"])); n != nil {
+				fields["network"] = n.fields["network"]
+			}
+		}
+	case "lease":
+		if _, ok := fields["network"]; !ok {
+			if n := s.containingNetwork(formatValue(fields["address"]), formatValue(fields["network_view"])); n != nil {
+				fields["network"] = n.fields["network"]
+			}
+		}
+	case "range":
+		start, end := formatValue(fields["start_addr"]), formatValue(fields["end_addr"])
+		n := s.containingNetwork(start, formatValue(fields["network_view"]))
+		if n == nil || n != s.containingNetwork(end, formatValue(fields["network_view"])) {
+			return dataError(fmt.Sprintf("Range %s-%s is not within a network", start, end))
+		}
+		fields["network"] = n.fields["network"]
+	case "record:aaaa":
+		fields["ipv6addr"] = canonicalIP(fields["ipv6addr"])
+	case "record:ptr":
+		if _, ok := fields["name"]; !ok {
+			ip := fields["ipv4addr"]
+			if ip == nil {
+				ip = fields["ipv6addr"]
+			}
+			if name := reverseName(formatValue(ip)); name != "" {
+				fields["name"] = name
+			}
+		}
+	case "record:host":
+		for _, field := range []string{"ipv4addr", "ipv6addr"} {
+			if err := s.completeHostAddrs(obj, fields, field); err != nil {
+				return err
+			}
+		}
+	}
+
+	if strings.HasPrefix(obj.objType, "record:") {
+		if zone := s.zoneOf(formatValue(fields["name"]), formatValue(fields["view"])); zone != "" {
+			fields["zone"] = zone
+		}
+	}
+	return nil
+}
+
+// completeHostAddrs sets the host and reference of the ipv4addrs or
+// ipv6addrs of a host record
+func (s *Simulator) completeHostAddrs(obj *object, fields map[string]interface{}, field string) error {
+	name, view := formatValue(fields["name"]), formatValue(fields["view"])
+	netview := formatValue(fields["network_view"])
+	if netview == "" {
+		netview = "default"
+	}
+	addrs, ok := fields[field+"s"].([]interface{})
+	if !ok {
+		return nil
+	}
+	for _, a := range addrs {
+		addr, ok := a.(map[string]interface{})
+		if !ok {
+			return protoError("Invalid value for " + field + "s")
+		}
+		addr[field] = canonicalIP(addr[field])
+		ip := formatValue(addr[field])
+		addr["host"] = name
+		addr["network_view"] = netview
+		if n := s.containingNetwork(ip, netview); n != nil {
+			addr["network"] = n.fields["network"]
+		}
+		addr["_ref"] = "record:host_" + field + "/" + newID(obj.id+"."+ip, 0) + ":" + ip + "/" + name + "/" + view
+	}
+	return nil
+}
+
+// canonicalIP returns the canonical form of an IP address value, other
+// values are returned unchanged
+func canonicalIP(v interface{}) interface{} {
+	if ip := net.ParseIP(formatValue(v)); ip != nil {
+		return ip.String()
+	}
+	return v
+}
+
+// zoneOf returns the authoritative zone holding name
+func (s *Simulator) zoneOf(name string, view string) string {
+	zone := ""
+	for _, z := range s.list("zone_auth") {
+		fqdn := formatValue(z.fields["fqdn"])
+		if formatValue(z.fields["view"]) != view || len(fqdn) <= len(zone) {
+			continue
+		}
+		if name == fqdn || strings.HasSuffix(name, "."+fqdn) {
+			zone = fqdn
+		}
+	}
+	return zone
+}
+
+func addValues(current interface{}, add interface{}) interface{} {
+	switch cur := current.(type) {
+	case map[string]interface{}:
+		if m, ok := add.(map[string]interface{}); ok {
+			for k, v := range m {
+				cur[k] = v
+			}
+			return cur
+		}
+	case []interface{}:
+		if l, ok := add.([]interface{}); ok {
+			return append(cur, l...)
+		}
+	case nil:
+		return add
+	}
+	return current
+}
+
+func removeValues(current interface{}, remove interface{}) interface{} {
+	switch cur := current.(type) {
+	case map[string]interface{}:
+		if m, ok := remove.(map[string]interface{}); ok {
+			for k := range m {
+				delete(cur, k)
+			}
+		}
+	case []interface{}:
+		l, ok := remove.([]interface{})
+		if !ok {
+			return current
+		}
+		res := []interface{}{}
+		for _, v := range cur {
+			removed := false
+			for _, r := range l {
+				if sameElement(v, r) {
+					removed = true
+					break
+				}
+			}
+			if !removed {
+				res = append(res, v)
+			}
+		}
+		return res
+	}
+	return current
+}
+
+// sameElement compares list elements, sub-objects such as host addresses
+// are identified by their address
+func sameElement(a, b interface{}) bool {
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if aok && bok {
+		for _, key := range []string{"ipv4addr", "ipv6addr", "name"} {
+			if v, ok := bm[key]; ok {
+				return formatValue(am[key]) == formatValue(v)
+			}
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			res[k] = deepCopy(e)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, e := range val {
+			res[i] = deepCopy(e)
+		}
+		return res
+	}
+	return v
+}
+
+// snapshot returns a copy of the stored objects, restored if a request
+// of the request endpoint fails
+func (s *Simulator) snapshot() map[string]*object {
+	res := make(map[string]*object, len(s.objects))
+	for id, obj := range s.objects {
+		cp := *obj
+		cp.fields = deepCopy(obj.fields).(map[string]interface{})
+		res[id] = &cp
+	}
+	return res
+}
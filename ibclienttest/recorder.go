@@ -0,0 +1,384 @@
+package ibclienttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	ibclient "jimrazmus/infoblox-go-client"
+)
+
+// Interaction is a WAPI request and its response, as stored in fixtures.
+// Only the method, path, query and body of requests are kept, so their
+// credentials and cookies are never recorded.
+type Interaction struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Query is encoded with sorted keys
+	Query string          `json:"query,omitempty"`
+	Body  json.RawMessage `json:"body,omitempty"`
+
+	// Status is 200 for every successful request
+	Status int `json:"status"`
+	// Response holds JSON responses, ResponseText the others, such as the
+	// HTML body of 401 errors
+	Response     json.RawMessage `json:"response,omitempty"`
+	ResponseText string          `json:"response_text,omitempty"`
+	// Error is the message of errors without a response, e.g. timeouts
+	Error string `json:"error,omitempty"`
+}
+
+// fixture is the content of a fixture file
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// scrubbedKeys are query arguments and JSON fields whose values are never
+// recorded
+var scrubbedKeys = []string{"password", "secret", "token", "authorization", "cookie", "ibapauth"}
+
+const scrubbed = "REDACTED"
+
+func isScrubbedKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, s := range scrubbedKeys {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubJSON replaces the values of sensitive fields at any depth
+func scrubJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, e := range val {
+			if isScrubbedKey(k) {
+				val[k] = scrubbed
+			} else {
+				val[k] = scrubJSON(e)
+			}
+		}
+	case []interface{}:
+		for i, e := range val {
+			val[i] = scrubJSON(e)
+		}
+	}
+	return v
+}
+
+// normalizeJSON returns data scrubbed and re-encoded with sorted keys, or
+// false if it isn't JSON
+func normalizeJSON(data []byte) (json.RawMessage, bool) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, true
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	b, err := json.Marshal(scrubJSON(v))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+func normalizeQuery(q url.Values) string {
+	for k := range q {
+		if isScrubbedKey(k) {
+			q[k] = []string{scrubbed}
+		}
+	}
+	return q.Encode()
+}
+
+// newInteraction returns the scrubbed request part of an Interaction,
+// restoring the body of req so it can still be sent
+func newInteraction(req *http.Request) (Interaction, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return Interaction{}, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	norm, ok := normalizeJSON(body)
+	if !ok {
+		return Interaction{}, fmt.Errorf("request body is not JSON: %q", body)
+	}
+	return Interaction{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  normalizeQuery(req.URL.Query()),
+		Body:   norm,
+	}, nil
+}
+
+func (i Interaction) matches(req Interaction) bool {
+	return i.Method == req.Method && i.Path == req.Path && i.Query == req.Query &&
+		bytes.Equal(i.Body, req.Body)
+}
+
+func (i Interaction) String() string {
+	s := i.Method + " " + i.Path
+	if i.Query != "" {
+		s += "?" + i.Query
+	}
+	if len(i.Body) > 0 {
+		s += " " + string(i.Body)
+	}
+	return s
+}
+
+// RecordingRequestor is an ibclient.HTTPRequestor sending requests with
+// another HTTPRequestor and recording the exchanges, to be saved as a
+// fixture for a ReplayingRequestor.
+//
+//	rec := ibclienttest.NewRecordingRequestor("testdata/allocate.json", &ibclient.WapiHTTPRequestor{})
+//	conn, err := ibclient.NewConnector(hostConfig, transportConfig, &ibclient.WapiRequestBuilder{}, rec)
+//	...
+//	err = rec.Save()
+type RecordingRequestor struct {
+	path      string
+	requestor ibclient.HTTPRequestor
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+var _ ibclient.HTTPRequestor = &RecordingRequestor{}
+
+// NewRecordingRequestor returns a RecordingRequestor saving to the fixture
+// file at path and sending the requests with requestor
+func NewRecordingRequestor(path string, requestor ibclient.HTTPRequestor) *RecordingRequestor {
+	return &RecordingRequestor{path: path, requestor: requestor}
+}
+
+// Init implements ibclient.HTTPRequestor
+func (r *RecordingRequestor) Init(cfg ibclient.TransportConfig) {
+	r.requestor.Init(cfg)
+}
+
+// SendRequest implements ibclient.HTTPRequestor
+func (r *RecordingRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	return r.SendRequestWithContext(req.Context(), req)
+}
+
+// SendRequestWithContext implements ibclient.HTTPRequestor
+func (r *RecordingRequestor) SendRequestWithContext(ctx context.Context, req *http.Request) ([]byte, error) {
+	in, err := newInteraction(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.requestor.SendRequestWithContext(ctx, req)
+	var wapiErr *ibclient.WapiError
+	switch {
+	case err == nil:
+		in.Status = http.StatusOK
+		in.setResponse(res)
+	case errors.As(err, &wapiErr):
+		in.Status = wapiErr.StatusCode
+		in.setResponse(wapiErr.Body)
+	default:
+		in.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, in)
+	r.mu.Unlock()
+
+	return res, err
+}
+
+func (i *Interaction) setResponse(body []byte) {
+	if norm, ok := normalizeJSON(body); ok {
+		i.Response = norm
+	} else {
+		i.ResponseText = string(body)
+	}
+}
+
+// Interactions returns the exchanges recorded so far
+func (r *RecordingRequestor) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded exchanges to the fixture file
+func (r *RecordingRequestor) Save() error {
+	b, err := json.MarshalIndent(fixture{Interactions: r.Interactions()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(b, '\n'), 0644)
+}
+
+// UnmatchedRequestError is returned by a ReplayingRequestor for requests
+// not found in its fixture
+type UnmatchedRequestError struct {
+	Request Interaction
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("no recorded interaction left for %s", e.Request)
+}
+
+// ReplayingRequestor is an ibclient.HTTPRequestor answering requests with
+// the responses of a fixture saved by a RecordingRequestor, without any
+// network access.
+//
+// Requests match recorded ones on their method, path, query and JSON body,
+// ignoring the order of keys. Each recorded exchange is replayed once, in
+// order, so repeated requests get the successive recorded responses.
+// Unmatched requests fail with an UnmatchedRequestError and fail the test
+// given to NewReplayingRequestor, if any.
+type ReplayingRequestor struct {
+	t testing.TB
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+var _ ibclient.HTTPRequestor = &ReplayingRequestor{}
+
+// NewReplayingRequestor returns a ReplayingRequestor for the fixture file at
+// path. t may be nil.
+func NewReplayingRequestor(t testing.TB, path string) (*ReplayingRequestor, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f fixture
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("cannot parse fixture %s: %w", path, err)
+	}
+	return NewReplayingRequestorFromInteractions(t, f.Interactions), nil
+}
+
+// NewReplayingRequestorFromInteractions returns a ReplayingRequestor for
+// the given exchanges, e.g. those of a RecordingRequestor. t may be nil.
+func NewReplayingRequestorFromInteractions(t testing.TB, interactions []Interaction) *ReplayingRequestor {
+	// fixtures may have been edited or indented
+	interactions = append([]Interaction(nil), interactions...)
+	for i := range interactions {
+		if norm, ok := normalizeJSON(interactions[i].Body); ok {
+			interactions[i].Body = norm
+		}
+		if q, err := url.ParseQuery(interactions[i].Query); err == nil {
+			interactions[i].Query = normalizeQuery(q)
+		}
+	}
+	return &ReplayingRequestor{
+		t:            t,
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// Init implements ibclient.HTTPRequestor, the configuration is ignored
+func (r *ReplayingRequestor) Init(cfg ibclient.TransportConfig) {}
+
+// SendRequest implements ibclient.HTTPRequestor
+func (r *ReplayingRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	return r.SendRequestWithContext(req.Context(), req)
+}
+
+// SendRequestWithContext implements ibclient.HTTPRequestor
+func (r *ReplayingRequestor) SendRequestWithContext(ctx context.Context, req *http.Request) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	in, err := newInteraction(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	recorded, ok := r.next(in)
+	r.mu.Unlock()
+	if !ok {
+		err := &UnmatchedRequestError{Request: in}
+		if r.t != nil {
+			r.t.Errorf("%v", err)
+		}
+		return nil, err
+	}
+
+	body := []byte(recorded.ResponseText)
+	if len(recorded.Response) > 0 {
+		body = recorded.Response
+	}
+	switch {
+	case recorded.Error != "":
+		return nil, errors.New(recorded.Error)
+	case recorded.Status != http.StatusOK && recorded.Status != http.StatusCreated:
+		return nil, ibclient.NewWapiError(req.Method, objectTypeOfPath(req.URL.Path), recorded.Status, body)
+	}
+	return body, nil
+}
+
+// next returns the first unused interaction matching in
+func (r *ReplayingRequestor) next(in Interaction) (Interaction, bool) {
+	for i, recorded := range r.interactions {
+		if !r.used[i] && recorded.matches(in) {
+			r.used[i] = true
+			return recorded, true
+		}
+	}
+	return Interaction{}, false
+}
+
+// Remaining returns the recorded exchanges which were not replayed
+func (r *ReplayingRequestor) Remaining() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []Interaction
+	for i, recorded := range r.interactions {
+		if !r.used[i] {
+			res = append(res, recorded)
+		}
+	}
+	return res
+}
+
+// AssertAllReplayed fails the test if some recorded exchanges were not
+// replayed
+func (r *ReplayingRequestor) AssertAllReplayed(t testing.TB) {
+	t.Helper()
+	for _, recorded := range r.Remaining() {
+		t.Errorf("recorded interaction was not replayed: %s", recorded)
+	}
+}
+
+// objectTypeOfPath returns the object type of a WAPI path such as
+// /wapi/v2.5/network/ZG5z...:10.0.0.0/24/default
+func objectTypeOfPath(p string) string {
+	if path, ok := wapiPath(p); ok {
+		return strings.SplitN(path, "/", 2)[0]
+	}
+	return ""
+}
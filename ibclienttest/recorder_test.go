@@ -0,0 +1,106 @@
+package ibclienttest
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ibclient "jimrazmus/infoblox-go-client"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	sim := NewSimulator()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec := NewRecordingRequestor(path, &ibclient.WapiHTTPRequestor{})
+	conn, err := ibclient.NewConnector(sim.HostConfig(), sim.TransportConfig(), &ibclient.WapiRequestBuilder{}, rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objMgr := ibclient.NewObjectManager(conn, "CMP", "tenant")
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	secret := ibclient.NewEADefinition(ibclient.EADefinition{Name: "Password", Comment: "holds secrets"})
+	_, _ = conn.CreateObject(&struct {
+		*ibclient.EADefinition
+		Password string `json:"password"`
+	}{secret, "hunter2"})
+	if _, err := conn.DeleteObject("network/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMjQvMA:10.1.0.0/24/default"); !errors.Is(err, ibclient.ErrNotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sim.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range []string{"hunter2", DefaultPassword, "ibapauth", "127.0.0.1"} {
+		if strings.Contains(string(b), s) {
+			t.Errorf("fixture contains %q:\n%s", s, b)
+		}
+	}
+
+	replay, err := NewReplayingRequestor(t, path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conn, err = ibclient.NewConnector(sim.HostConfig(), ibclient.NewTransportConfig("false", 1, 1), &ibclient.WapiRequestBuilder{}, replay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objMgr = ibclient.NewObjectManager(conn, "CMP", "tenant")
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"10.0.0.1", "10.0.0.2"} {
+		fixedAddr, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm", nil)
+		if err != nil || fixedAddr.IPAddress != expected {
+			t.Errorf("expected %s, got %+v, error %v", expected, fixedAddr, err)
+		}
+	}
+	if len(replay.Remaining()) != 2 {
+		t.Errorf("unexpected remaining interactions %v", replay.Remaining())
+	}
+	if _, err := conn.DeleteObject("network/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMjQvMA:10.1.0.0/24/default"); !errors.Is(err, ibclient.ErrNotFound) {
+		t.Errorf("expected a replayed not found error, got %v", err)
+	}
+}
+
+func TestReplayUnmatchedRequest(t *testing.T) {
+	replay := NewReplayingRequestorFromInteractions(nil, []Interaction{
+		{Method: "GET", Path: "/wapi/v2.5/userprofile", Query: "_return_fields=name", Body: []byte(`{}`), Status: 200, Response: []byte(`[{"name": "admin"}]`)},
+		{Method: "GET", Path: "/wapi/v2.5/network", Query: "_return_fields=extattrs,network,network_view,options", Body: []byte(`{
+			"network_view": "default",
+			"network": "10.0.0.0/24"
+		}`), Status: 200, Response: []byte(`[{"network": "10.0.0.0/24"}]`)},
+	})
+	conn, err := ibclient.NewConnector(ibclient.HostConfig{Host: "localhost", Version: "2.5", Port: "443"},
+		ibclient.NewTransportConfig("false", 1, 1), &ibclient.WapiRequestBuilder{}, replay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the keys of the body are ordered differently
+	var res []ibclient.Network
+	if err := conn.GetObject(ibclient.NewNetwork(ibclient.Network{NetviewName: "default", Cidr: "10.0.0.0/24"}), "", &res); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = conn.GetObject(ibclient.NewNetwork(ibclient.Network{NetviewName: "default", Cidr: "10.0.1.0/24"}), "", &res)
+	var unmatched *UnmatchedRequestError
+	if !errors.As(err, &unmatched) || unmatched.Request.Method != "GET" {
+		t.Errorf("expected an unmatched request error, got %v", err)
+	}
+	replay.AssertAllReplayed(t)
+}
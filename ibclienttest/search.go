@@ -0,0 +1,255 @@
+package ibclienttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxResults is the number of results above which WAPI fails a
+// search without _max_results
+const defaultMaxResults = 1000
+
+// condition is a search argument of a GET request
+type condition struct {
+	field string
+	ea    bool
+	mods  string
+	value interface{}
+}
+
+// parseConditions returns the exact matches of the request body and the
+// search arguments of the URL, which may carry modifiers
+func parseConditions(body []byte, args url.Values) ([]condition, error) {
+	fields, err := decodeFields(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var conds []condition
+	for k, v := range fields {
+		conds = append(conds, newCondition(k, "", v))
+	}
+	for k, values := range args {
+		if strings.HasPrefix(k, "_") {
+			continue
+		}
+		field := strings.TrimRight(k, "!:~<>")
+		for _, v := range values {
+			conds = append(conds, newCondition(field, k[len(field):], v))
+		}
+	}
+	return conds, nil
+}
+
+func newCondition(field string, mods string, value interface{}) condition {
+	if strings.HasPrefix(field, "*") {
+		return condition{field: field[1:], ea: true, mods: mods, value: value}
+	}
+	return condition{field: field, mods: mods, value: value}
+}
+
+// matches reports whether the object matches every condition
+func (obj *object) matches(conds []condition) bool {
+	for _, c := range conds {
+		if !c.matches(obj.values(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// values returns the values of the searched field. Fields of sub-objects,
+// such as the addresses of host records, are searched too.
+func (obj *object) values(c condition) []interface{} {
+	var v interface{}
+	var ok bool
+	if c.ea {
+		eas, _ := obj.fields["extattrs"].(map[string]interface{})
+		ea, _ := eas[c.field].(map[string]interface{})
+		v, ok = ea["value"]
+	} else {
+		v, ok = obj.fields[c.field]
+	}
+
+	if !ok {
+		var res []interface{}
+		for _, f := range obj.fields {
+			list, _ := f.([]interface{})
+			for _, e := range list {
+				if m, ok := e.(map[string]interface{}); ok && m[c.field] != nil {
+					res = append(res, m[c.field])
+				}
+			}
+		}
+		return res
+	}
+	if list, ok := v.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{v}
+}
+
+func (c condition) matches(values []interface{}) bool {
+	negate := strings.Contains(c.mods, "!")
+	for _, v := range values {
+		if c.matchValue(v) {
+			return !negate
+		}
+	}
+	return negate
+}
+
+func (c condition) matchValue(v interface{}) bool {
+	got, want := formatValue(v), formatValue(c.value)
+	_, isBool := v.(bool)
+	fold := isBool || strings.Contains(c.mods, ":")
+
+	switch {
+	case strings.Contains(c.mods, "~"):
+		expr := want
+		if fold {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		return err == nil && re.MatchString(got)
+	case strings.Contains(c.mods, "<"):
+		return compareValues(got, want) <= 0
+	case strings.Contains(c.mods, ">"):
+		return compareValues(got, want) >= 0
+	case fold:
+		return strings.EqualFold(got, want)
+	}
+	return got == want
+}
+
+// compareValues compares numbers and IP addresses by value, other values
+// as strings
+func compareValues(a, b string) int {
+	af, aErr := strconv.ParseFloat(a, 64)
+	bf, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+
+	if aIP, bIP := net.ParseIP(a), net.ParseIP(b); aIP != nil && bIP != nil {
+		return bytes.Compare(aIP.To16(), bIP.To16())
+	}
+	return strings.Compare(a, b)
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func (s *Simulator) search(objType string, body []byte, args url.Values) (interface{}, error) {
+	kind, ok := objectKinds[objType]
+	if !ok {
+		if kind, ok = hostAddrKinds[objType]; !ok {
+			if kind, ok = ipAddressKinds[objType]; !ok {
+				return nil, unknownObjectType(objType)
+			}
+		}
+	}
+
+	if id := args.Get("_page_id"); id != "" {
+		p, ok := s.pages[id]
+		if !ok {
+			return nil, protoError(fmt.Sprintf("Page id %s is not valid", id))
+		}
+		delete(s.pages, id)
+		return s.page(p.results, p.size), nil
+	}
+
+	conds, err := parseConditions(body, args)
+	if err != nil {
+		return nil, err
+	}
+	objects := s.list(objType)
+	if _, ok := hostAddrKinds[objType]; ok {
+		objects = s.hostAddrs(strings.TrimPrefix(objType, "record:host_"))
+	} else if _, ok := ipAddressKinds[objType]; ok {
+		if objects, err = s.searchIPAddresses(objType, conds); err != nil {
+			return nil, err
+		}
+	}
+	fields := returnFields(kind, args)
+	results := []interface{}{}
+	for _, obj := range objects {
+		if obj.matches(conds) {
+			results = append(results, obj.render(fields))
+		}
+	}
+
+	maxResults := -defaultMaxResults
+	if v := args.Get("_max_results"); v != "" {
+		if maxResults, err = strconv.Atoi(v); err != nil || maxResults == 0 {
+			return nil, protoError("Invalid value for _max_results: " + v)
+		}
+	}
+	asObject := args.Get("_return_as_object") == "1"
+
+	if args.Get("_paging") == "1" {
+		if !asObject {
+			return nil, protoError("_return_as_object needs to be enabled for paging requests.")
+		}
+		if maxResults < 0 {
+			maxResults = -maxResults
+		}
+		return s.page(results, maxResults), nil
+	}
+
+	switch {
+	case maxResults > 0 && len(results) > maxResults:
+		results = results[:maxResults]
+	case maxResults < 0 && len(results) > -maxResults:
+		return nil, protoError(fmt.Sprintf("Result set too large (> %d)", -maxResults))
+	}
+	if asObject {
+		return map[string]interface{}{"result": results}, nil
+	}
+	return results, nil
+}
+
+// pendingPage holds the results of a paged search not returned yet
+type pendingPage struct {
+	results []interface{}
+	size    int
+}
+
+// page returns the first size results and remembers the others under the
+// returned next_page_id
+func (s *Simulator) page(results []interface{}, size int) map[string]interface{} {
+	if len(results) <= size {
+		return map[string]interface{}{"result": results}
+	}
+
+	id := newToken()
+	s.pages[id] = &pendingPage{results: results[size:], size: size}
+	return map[string]interface{}{"result": results[:size], "next_page_id": id}
+}
@@ -0,0 +1,284 @@
+// Package ibclienttest provides test doubles for code using the ibclient
+// package, such as an in-memory WAPI server.
+package ibclienttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	ibclient "jimrazmus/infoblox-go-client"
+)
+
+// Default credentials accepted by a Simulator, those of a new NIOS appliance
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "infoblox"
+)
+
+// Simulator is an in-memory WAPI server for tests. It stores the created
+// objects, generates realistic references, honors _return_fields, field and
+// extensible attribute searches, paging, func:nextavailableip and
+// func:nextavailablenetwork, the next_available_ip and
+// next_available_network functions, the request endpoint and answers with
+// the error bodies of a real Grid.
+//
+//	sim := ibclienttest.NewSimulator()
+//	defer sim.Close()
+//	conn, err := sim.NewConnector()
+type Simulator struct {
+	// Username and Password are the accepted credentials, requests are not
+	// authenticated if both are empty. They are sent by the HostConfig.
+	Username string
+	Password string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	objects  map[string]*object
+	pages    map[string]*pendingPage
+	sessions map[string]bool
+}
+
+// NewSimulator starts a Simulator holding the objects of a new Grid: the
+// default network view and DNS view and the admin user profile
+func NewSimulator() *Simulator {
+	s := &Simulator{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		objects:  make(map[string]*object),
+		pages:    make(map[string]*pendingPage),
+		sessions: make(map[string]bool),
+	}
+	s.server = httptest.NewTLSServer(s)
+
+	s.mustAdd("networkview", map[string]interface{}{"name": "default", "is_default": true})
+	s.mustAdd("view", map[string]interface{}{"name": "default", "is_default": true, "network_view": "default"})
+	s.mustAdd("userprofile", map[string]interface{}{"name": DefaultUsername})
+
+	return s
+}
+
+// Close shuts the Simulator down
+func (s *Simulator) Close() {
+	s.server.Close()
+}
+
+// URL returns the base URL of the Simulator, e.g. https://127.0.0.1:43567
+func (s *Simulator) URL() string {
+	return s.server.URL
+}
+
+// HostConfig returns a HostConfig for the Simulator
+func (s *Simulator) HostConfig() ibclient.HostConfig {
+	u, _ := url.Parse(s.server.URL)
+	return ibclient.HostConfig{
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		Version:  "2.5",
+		Username: s.Username,
+		Password: s.Password,
+	}
+}
+
+// TransportConfig returns a TransportConfig trusting the certificate of the
+// Simulator
+func (s *Simulator) TransportConfig() ibclient.TransportConfig {
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw})
+	cfg, err := ibclient.NewTransportConfigWithTLS(ibclient.TLSConfig{CAPEMs: [][]byte{ca}}, 5, 1)
+	if err != nil {
+		panic(err) // the certificate of httptest is always valid
+	}
+	return cfg
+}
+
+// NewConnector returns a Connector talking to the Simulator
+func (s *Simulator) NewConnector() (*ibclient.Connector, error) {
+	return ibclient.NewConnector(s.HostConfig(), s.TransportConfig(),
+		&ibclient.WapiRequestBuilder{}, &ibclient.WapiHTTPRequestor{})
+}
+
+// Add stores an object as if it had been created with a POST request, e.g.
+// to seed the Simulator before a test, and returns its reference
+func (s *Simulator) Add(objType string, fields map[string]interface{}) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	res, err := s.create(objType, data, url.Values{})
+	if err != nil {
+		return "", err
+	}
+	return res.(string), nil
+}
+
+func (s *Simulator) mustAdd(objType string, fields map[string]interface{}) {
+	if _, err := s.Add(objType, fields); err != nil {
+		panic(err)
+	}
+}
+
+// Object returns all the fields of the object with the given reference
+func (s *Simulator) Object(ref string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj := s.lookup(ref)
+	if obj == nil {
+		return nil, false
+	}
+	return obj.render(nil), true
+}
+
+// Objects returns all the fields of every object of the given type, in
+// creation order
+func (s *Simulator) Objects(objType string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []map[string]interface{}
+	for _, obj := range s.list(objType) {
+		res = append(res, obj.render(nil))
+	}
+	return res
+}
+
+// ServeHTTP implements http.Handler
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, ok := wapiPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, protoError("Cannot read request body"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authenticate(w, r) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("<html><head><title>401 Authorization Required</title></head><body>Authorization Required</body></html>"))
+		return
+	}
+
+	if path == "logout" && r.Method == http.MethodPost {
+		if c, err := r.Cookie("ibapauth"); err == nil {
+			delete(s.sessions, c.Value)
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	res, err := s.dispatch(r.Method, path, r.URL.Query(), body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if r.Method == http.MethodPost && path != "request" {
+		status = http.StatusCreated
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// wapiPath strips the /wapi/vX.Y/ prefix of a request path
+func wapiPath(p string) (string, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(p, "/"), "/", 3)
+	if len(parts) != 3 || parts[0] != "wapi" || !strings.HasPrefix(parts[1], "v") {
+		return "", false
+	}
+	return parts[2], true
+}
+
+// authenticate checks the credentials or the ibapauth session cookie,
+// starting a session when the credentials are valid
+func (s *Simulator) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	if s.Username == "" && s.Password == "" {
+		return true
+	}
+
+	if user, pass, ok := r.BasicAuth(); ok {
+		if user != s.Username || pass != s.Password {
+			return false
+		}
+		token := newToken()
+		s.sessions[token] = true
+		http.SetCookie(w, &http.Cookie{Name: "ibapauth", Value: token, Path: "/", Secure: true, HttpOnly: true})
+		return true
+	}
+
+	c, err := r.Cookie("ibapauth")
+	return err == nil && s.sessions[c.Value]
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// dispatch executes a single WAPI request on the store
+func (s *Simulator) dispatch(method string, path string, args url.Values, body []byte) (interface{}, error) {
+	if path == "request" {
+		if method != http.MethodPost {
+			return nil, protoError("The request object only supports POST")
+		}
+		return s.multiRequest(body)
+	}
+
+	if isRef(path) {
+		switch method {
+		case http.MethodGet:
+			return s.read(path, args)
+		case http.MethodPut:
+			return s.update(path, body, args)
+		case http.MethodDelete:
+			return s.delete(path)
+		case http.MethodPost:
+			if function := args.Get("_function"); function != "" {
+				return s.callFunction(path, function, body)
+			}
+		}
+		return nil, protoError("Method " + method + " is not allowed on a reference")
+	}
+
+	switch method {
+	case http.MethodGet:
+		return s.search(path, body, args)
+	case http.MethodPost:
+		return s.create(path, body, args)
+	}
+	return nil, protoError("Method " + method + " is not allowed on an object type")
+}
+
+// list returns the objects of objType in creation order
+func (s *Simulator) list(objType string) []*object {
+	var res []*object
+	for _, obj := range s.objects {
+		if obj.objType == objType {
+			res = append(res, obj)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].seq < res[j].seq })
+	return res
+}
@@ -0,0 +1,919 @@
+package ibclienttest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	ibclient "jimrazmus/infoblox-go-client"
+)
+
+func newTestObjectManager(t *testing.T) (*Simulator, *ibclient.Connector, *ibclient.ObjectManager) {
+	sim := NewSimulator()
+	t.Cleanup(sim.Close)
+
+	conn, err := sim.NewConnector()
+	if err != nil {
+		t.Fatalf("cannot connect to the simulator: %v", err)
+	}
+	return sim, conn, ibclient.NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+}
+
+func TestSimulatorAllocateIP(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"10.0.0.1", "10.0.0.2"} {
+		fixedAddr, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fixedAddr.IPAddress != expected {
+			t.Errorf("got %s, expected %s", fixedAddr.IPAddress, expected)
+		}
+	}
+
+	if _, err := objMgr.ReleaseIP("default", "10.0.0.0/24", "10.0.0.1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fixedAddr, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm", nil)
+	if err != nil || fixedAddr.IPAddress != "10.0.0.1" {
+		t.Errorf("expected the released address to be allocated again, got %+v, %v", fixedAddr, err)
+	}
+
+	_, err = objMgr.AllocateIP("default", "10.0.0.0/24", "10.0.0.2", "", "vm", nil)
+	if !errors.Is(err, ibclient.ErrDuplicate) {
+		t.Errorf("expected a duplicate error, got %v", err)
+	}
+}
+
+func TestSimulatorAllocateNetwork(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetworkContainer("default", "10.0.0.0/16"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/25", "taken"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{"10.0.1.0/24", "10.0.2.0/24"} {
+		network, err := objMgr.AllocateNetwork("default", "10.0.0.0/16", 24, "app")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if network.Cidr != expected {
+			t.Errorf("got %s, expected %s", network.Cidr, expected)
+		}
+	}
+
+	if _, err := objMgr.AllocateNetwork("default", "10.0.0.0/16", 15, "app"); err == nil {
+		t.Error("expected an error for a prefix shorter than the container")
+	}
+}
+
+func TestSimulatorSearch(t *testing.T) {
+	sim, conn, objMgr := newTestObjectManager(t)
+
+	for _, cidr := range []string{"10.0.0.0/24", "10.0.1.0/24", "192.168.0.0/24"} {
+		if _, err := sim.Add("network", map[string]interface{}{
+			"network":  cidr,
+			"extattrs": map[string]interface{}{"Site": map[string]interface{}{"value": "lab"}},
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	network, err := objMgr.GetNetwork("default", "10.0.1.0/24", ibclient.EA{"Site": "lab"})
+	if err != nil || network == nil || network.Ea["Site"] != "lab" {
+		t.Fatalf("unexpected network %+v, error %v", network, err)
+	}
+
+	networks, err := objMgr.SearchNetworks(ibclient.NewQuery().Regex("network", `^10\.`).EAEqual("Site", "lab"))
+	if err != nil || len(networks) != 2 {
+		t.Errorf("expected 2 networks, got %+v, error %v", networks, err)
+	}
+
+	var all []ibclient.Network
+	if err := ibclient.GetAllObjects(conn, ibclient.NewNetwork(ibclient.Network{}), 2, &all); err != nil || len(all) != 3 {
+		t.Errorf("expected 3 networks over 2 pages, got %+v, error %v", all, err)
+	}
+
+	// extattrs is always returned when requested, even if empty
+	var nv ibclient.NetworkView
+	nvObj := ibclient.NewNetworkView(ibclient.NetworkView{})
+	if err := conn.GetObject(nvObj, sim.Objects("networkview")[0]["_ref"].(string), &nv); err != nil || nv.Ea == nil {
+		t.Errorf("unexpected network view %+v, error %v", nv, err)
+	}
+}
+
+func TestSimulatorMultiRequest(t *testing.T) {
+	sim, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := ibclient.NewMultiRequest([]*ibclient.RequestBody{
+		{
+			Method:      "GET",
+			Object:      "network",
+			Data:        map[string]interface{}{"network": "10.0.0.0/24"},
+			AssignState: map[string]string{"net_ref": "_ref"},
+			Discard:     true,
+		},
+		{
+			Method:             "POST",
+			Object:             "fixedaddress",
+			Data:               map[string]interface{}{"ipv4addr": "func:nextavailableip:##STATE:net_ref:##", "mac": "00:00:00:00:00:00"},
+			Args:               map[string]string{"_return_fields": "ipv4addr"},
+			EnableSubstitution: true,
+		},
+	})
+	res, err := objMgr.CreateMultiObject(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 1 || res[0]["ipv4addr"] != "10.0.0.1" {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	// a failing request rolls the previous ones back
+	req = ibclient.NewMultiRequest([]*ibclient.RequestBody{
+		{Method: "POST", Object: "fixedaddress", Data: map[string]interface{}{"ipv4addr": "10.0.0.5", "mac": "00:00:00:00:00:00"}},
+		{Method: "POST", Object: "fixedaddress", Data: map[string]interface{}{"ipv4addr": "10.0.0.1", "mac": "00:00:00:00:00:00"}},
+	})
+	if _, err := objMgr.CreateMultiObject(req); !errors.Is(err, ibclient.ErrDuplicate) {
+		t.Errorf("expected a duplicate error, got %v", err)
+	}
+	if n := len(sim.Objects("fixedaddress")); n != 1 {
+		t.Errorf("expected a single fixed address after the rollback, got %d", n)
+	}
+}
+
+func TestSimulatorNetworkViewLock(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetworkView("lab"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lock := &ibclient.NetworkViewLock{Name: "lab", ObjMgr: objMgr, LockEA: "Docker-Plugin-Lock", LockTimeoutEA: "Docker-Plugin-Lock-Time"}
+	if err := lock.Lock(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	view, err := objMgr.GetNetworkView("lab")
+	if err != nil || view.Ea["Docker-Plugin-Lock"] != "01234567890abcdef01234567890abcdef" || view.Ea["Docker-Plugin-Lock-Time"] == nil {
+		t.Errorf("unexpected locked network view %+v, error %v", view, err)
+	}
+
+	if err := lock.UnLock(false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	view, err = objMgr.GetNetworkView("lab")
+	if _, ok := view.Ea["Docker-Plugin-Lock-Time"]; err != nil || view.Ea["Docker-Plugin-Lock"] != "Available" || ok {
+		t.Errorf("unexpected unlocked network view %+v, error %v", view, err)
+	}
+}
+
+func TestSimulatorErrors(t *testing.T) {
+	_, conn, objMgr := newTestObjectManager(t)
+
+	_, err := conn.DeleteObject("network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default")
+	if !errors.Is(err, ibclient.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	zone, err := objMgr.CreateZoneAuth("example.com", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec, err := objMgr.CreateCNAMERecord("web.example.com", "www.example.com", "default", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec, err = objMgr.GetCNAMERecordByRef(rec.Ref); err != nil || rec.Zone != zone.Fqdn {
+		t.Fatalf("unexpected record %+v, error %v", rec, err)
+	}
+	_, err = conn.UpdateObject(ibclient.NewRecordCNAME(ibclient.RecordCNAME{Zone: "example.org"}), rec.Ref)
+	var wapiErr *ibclient.WapiError
+	if !errors.As(err, &wapiErr) || wapiErr.Text != "Field is not writable: zone" {
+		t.Errorf("expected a read-only field error, got %v", err)
+	}
+}
+
+func TestSimulatorSessionAuth(t *testing.T) {
+	sim := NewSimulator()
+	defer sim.Close()
+
+	hostCfg := sim.HostConfig()
+	hostCfg.AuthMode = ibclient.AuthSession
+	conn, err := ibclient.NewConnector(hostCfg, sim.TransportConfig(), &ibclient.WapiRequestBuilder{}, &ibclient.WapiHTTPRequestor{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := conn.Logout(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	hostCfg.Password = "wrong"
+	if _, err := ibclient.NewConnector(hostCfg, sim.TransportConfig(), &ibclient.WapiRequestBuilder{}, &ibclient.WapiHTTPRequestor{}); !errors.Is(err, ibclient.ErrUnauthorized) {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestSimulatorIPv6(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateIPv6NetworkContainer("default", "2001:db8::/48"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	network, err := objMgr.AllocateIPv6Network("default", "2001:db8::/48", 64, "app")
+	if err != nil || network.Cidr != "2001:db8::/64" {
+		t.Fatalf("unexpected network %+v, error %v", network, err)
+	}
+	if found, err := objMgr.GetIPv6Network("default", "2001:db8::/64", nil); err != nil || found == nil || found.Ref != network.Ref {
+		t.Errorf("unexpected network %+v, error %v", found, err)
+	}
+
+	fixedAddr, err := objMgr.AllocateIPv6("default", "2001:db8::/64", "", "00:01:00:01:aa:bb", "vm", nil)
+	if err != nil || fixedAddr.IPAddress != "2001:db8::1" {
+		t.Fatalf("unexpected fixed address %+v, error %v", fixedAddr, err)
+	}
+	if fixedAddr, err = objMgr.GetIPv6FixedAddressByRef(fixedAddr.Ref); err != nil || fixedAddr.Duid != "00:01:00:01:aa:bb" || fixedAddr.Cidr != "2001:db8::/64" {
+		t.Errorf("unexpected fixed address %+v, error %v", fixedAddr, err)
+	}
+
+	host, err := objMgr.CreateIPv6HostRecord(false, "vm.example.com", "default", "default", "2001:db8::/64", "", "00:01:00:01:cc:dd", nil)
+	if err != nil || len(host.Ipv6Addrs) != 1 || host.Ipv6Addrs[0].Ipv6Addr != "2001:db8::2" {
+		t.Fatalf("unexpected host %+v, error %v", host, err)
+	}
+	if ip, err := objMgr.GetIPv6AddressFromHostRecord(*host); err != nil || ip != "2001:db8::2" {
+		t.Errorf("unexpected address %q, error %v", ip, err)
+	}
+
+	if ref, err := objMgr.ReleaseIPv6("default", "2001:db8::/64", "2001:db8::1", ""); err != nil || ref == "" {
+		t.Errorf("unexpected reference %q, error %v", ref, err)
+	}
+	if fixedAddr, err := objMgr.GetIPv6FixedAddress("default", "2001:db8::/64", "2001:db8::1", ""); err != nil || fixedAddr != nil {
+		t.Errorf("unexpected fixed address %+v, error %v", fixedAddr, err)
+	}
+	if ref, err := objMgr.DeleteNetwork(network.Ref, "default"); err != nil || ref != network.Ref {
+		t.Errorf("unexpected reference %q, error %v", ref, err)
+	}
+}
+
+func TestSimulatorAAAARecord(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateZoneAuth("example.com", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateIPv6Network("default", "2001:db8::/64", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec, err := objMgr.CreateAAAARecord("default", "default", "www.example.com", "2001:db8::/64", "", ibclient.EA{"Site": "lab"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec, err = objMgr.GetAAAARecordByRef(rec.Ref); err != nil || rec.Ipv6Addr != "2001:db8::1" || rec.Zone != "example.com" || rec.Ea["Site"] != "lab" {
+		t.Fatalf("unexpected record %+v, error %v", rec, err)
+	}
+
+	for _, search := range [][3]string{{"www.example.com", "", ""}, {"", "2001:db8::1", ""}, {"", "", "example.com"}} {
+		recs, err := objMgr.GetAAAARecords("default", search[0], search[1], search[2], nil)
+		if err != nil || len(recs) != 1 || recs[0].Ref != rec.Ref {
+			t.Errorf("%v: unexpected records %+v, error %v", search, recs, err)
+		}
+	}
+	if recs, err := objMgr.GetAAAARecords("", "", "", "", ibclient.EA{"Site": "lab"}); err != nil || len(recs) != 1 {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+
+	updated, err := objMgr.UpdateAAAARecord(rec.Ref, "web.example.com", "2001:db8::10", ibclient.Uint32Ptr(600), ibclient.StringPtr("moved"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec, err = objMgr.GetAAAARecordByRef(updated.Ref); err != nil || rec.Name != "web.example.com" || rec.Ipv6Addr != "2001:db8::10" ||
+		rec.TTL != 600 || !*rec.UseTTL || rec.Comment != "moved" || rec.Ea["Site"] != "lab" {
+		t.Errorf("unexpected record %+v, error %v", rec, err)
+	}
+	if _, err = objMgr.UpdateAAAARecord(rec.Ref, "", "", nil, ibclient.StringPtr(""), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec, err = objMgr.GetAAAARecordByRef(rec.Ref); err != nil || rec.TTL != 600 || !*rec.UseTTL || rec.Comment != "" {
+		t.Errorf("unexpected record %+v, error %v", rec, err)
+	}
+
+	if ref, err := objMgr.DeleteAAAARecord(rec.Ref); err != nil || ref != rec.Ref {
+		t.Errorf("unexpected reference %q, error %v", ref, err)
+	}
+	if recs, err := objMgr.SearchAAAARecords(ibclient.NewQuery().Regex("name", "example")); err != nil || len(recs) != 0 {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+}
+
+func TestSimulatorMXAndSRVRecords(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateZoneAuth("example.com", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mx, err := objMgr.CreateMXRecord("default", "example.com", "mail.example.com", 0, 0, ibclient.EA{"Site": "lab"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mx, err = objMgr.GetMXRecordByRef(mx.Ref); err != nil || mx.Preference == nil || *mx.Preference != 0 ||
+		mx.UseTTL == nil || *mx.UseTTL || mx.Zone != "example.com" || mx.Ea["Site"] != "lab" {
+		t.Fatalf("unexpected record %+v, error %v", mx, err)
+	}
+	if mx, err = objMgr.UpdateMXRecord(mx.Ref, "", "", ibclient.Uint32Ptr(10), ibclient.Uint32Ptr(3600), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recs, err := objMgr.GetMXRecords("default", "", "mail.example.com"); err != nil || len(recs) != 1 ||
+		*recs[0].Preference != 10 || recs[0].TTL != 3600 || !*recs[0].UseTTL || recs[0].Ea["Site"] != "lab" {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+	// the preference and TTL are left unchanged when changing the exchanger
+	if mx, err = objMgr.UpdateMXRecord(mx.Ref, "", "mail2.example.com", nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recs, err := objMgr.GetMXRecords("default", "", "mail2.example.com"); err != nil || len(recs) != 1 ||
+		*recs[0].Preference != 10 || recs[0].TTL != 3600 || !*recs[0].UseTTL {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+
+	srv, err := objMgr.CreateSRVRecord("default", "_ldap._tcp.example.com", "dc.example.com", 0, 100, 389, 300, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if srv, err = objMgr.GetSRVRecordByRef(srv.Ref); err != nil || *srv.Priority != 0 || *srv.Weight != 100 ||
+		*srv.Port != 389 || srv.TTL != 300 || !*srv.UseTTL {
+		t.Fatalf("unexpected record %+v, error %v", srv, err)
+	}
+	if _, err := objMgr.CreateSRVRecord("default", "_ldap._tcp.example.com", "dc.example.com", 0, 100, 389, 0, nil); err == nil {
+		t.Error("expected an error for a duplicate record")
+	}
+	if srv, err = objMgr.UpdateSRVRecord(srv.Ref, "", "dc2.example.com", nil, ibclient.Uint32Ptr(50), nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if srv, err = objMgr.GetSRVRecordByRef(srv.Ref); err != nil || srv.Target != "dc2.example.com" || *srv.Priority != 0 ||
+		*srv.Weight != 50 || *srv.Port != 389 || srv.TTL != 300 || !*srv.UseTTL {
+		t.Errorf("unexpected record %+v, error %v", srv, err)
+	}
+
+	if _, err := objMgr.DeleteMXRecord(mx.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.DeleteSRVRecord(srv.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if recs, err := objMgr.SearchSRVRecords(ibclient.NewQuery().Regex("name", "example")); err != nil || len(recs) != 0 {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+}
+
+func TestSimulatorNSAndCAARecords(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateZoneAuth("example.com", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	addrs := []ibclient.NSRecordAddress{{Address: "192.0.2.53", AutoCreatePtr: true}}
+	ns, err := objMgr.CreateNSRecord("default", "sub.example.com", "ns1.sub.example.com", addrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ns, err = objMgr.GetNSRecordByRef(ns.Ref); err != nil || len(ns.Addresses) != 1 || ns.Addresses[0] != addrs[0] {
+		t.Fatalf("unexpected record %+v, error %v", ns, err)
+	}
+	if ns, err = objMgr.UpdateNSRecord(ns.Ref, "ns2.sub.example.com", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recs, err := objMgr.GetNSRecords("default", "sub.example.com", ""); err != nil || len(recs) != 1 ||
+		recs[0].Nameserver != "ns2.sub.example.com" || len(recs[0].Addresses) != 1 {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+
+	caa, err := objMgr.CreateCAARecord("default", "example.com", 0, "issue", "letsencrypt.org", 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if caa, err = objMgr.UpdateCAARecord(caa.Ref, "", ibclient.Uint32Ptr(128), "", "", ibclient.Uint32Ptr(60), ibclient.EA{"Site": "lab"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recs, err := objMgr.GetCAARecords("default", "example.com", "issue"); err != nil || len(recs) != 1 ||
+		*recs[0].CAFlag != 128 || recs[0].CAValue != "letsencrypt.org" || recs[0].TTL != 60 || recs[0].Ea["Site"] != "lab" {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+
+	if _, err := objMgr.DeleteNSRecord(ns.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.DeleteCAARecord(caa.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if recs, err := objMgr.SearchCAARecords(ibclient.NewQuery()); err != nil || len(recs) != 0 {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+}
+
+func TestSimulatorUpdateRecords(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateZoneAuth("example.com", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateZoneAuth("0.0.10.in-addr.arpa", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a, err := objMgr.CreateARecord("default", "default", "www.example.com", "", "10.0.0.1", ibclient.EA{"Site": "lab"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a, err = objMgr.UpdateARecord(a.Ref, "web.example.com", "10.0.0.2", ibclient.Uint32Ptr(600), ibclient.StringPtr("moved"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a, err = objMgr.GetARecordByRef(a.Ref); err != nil || a.Name != "web.example.com" || a.Ipv4Addr != "10.0.0.2" ||
+		a.TTL != 600 || !*a.UseTTL || a.Comment != "moved" || a.Zone != "example.com" || a.Ea["Site"] != "lab" {
+		t.Errorf("unexpected record %+v, error %v", a, err)
+	}
+	if a, err = objMgr.UpdateARecord(a.Ref, "", "", nil, ibclient.StringPtr(""), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a, err = objMgr.GetARecordByRef(a.Ref); err != nil || a.Name != "web.example.com" || a.TTL != 600 ||
+		!*a.UseTTL || a.Comment != "" {
+		t.Errorf("unexpected record %+v, error %v", a, err)
+	}
+
+	ptr, err := objMgr.CreatePTRRecord("default", "default", "www.example.com", "", "10.0.0.1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = objMgr.UpdatePTRRecord(ptr.Ref, "", "", "", ibclient.Uint32Ptr(300), ibclient.StringPtr("lab"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ptr, err = objMgr.UpdatePTRRecord(ptr.Ref, "web.example.com", "", "", nil, nil, ibclient.EA{"Site": "lab"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ptr, err = objMgr.GetPTRRecordByRef(ptr.Ref); err != nil || ptr.PtrdName != "web.example.com" ||
+		ptr.Ipv4Addr != "10.0.0.1" || ptr.TTL != 300 || !*ptr.UseTTL || ptr.Comment != "lab" || ptr.Ea["Site"] != "lab" {
+		t.Errorf("unexpected record %+v, error %v", ptr, err)
+	}
+
+	cname, err := objMgr.CreateCNAMERecord("web.example.com", "www.example.com", "default", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cname, err = objMgr.UpdateCNAMERecord(cname.Ref, "app.example.com", "", ibclient.Uint32Ptr(60), nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cname, err = objMgr.GetCNAMERecordByRef(cname.Ref); err != nil || cname.Canonical != "app.example.com" ||
+		cname.Name != "www.example.com" || cname.TTL != 60 || cname.Zone != "example.com" {
+		t.Errorf("unexpected record %+v, error %v", cname, err)
+	}
+}
+
+func TestSimulatorSearchRecords(t *testing.T) {
+	sim, _, objMgr := newTestObjectManager(t)
+
+	if _, err := sim.Add("view", map[string]interface{}{"name": "internal"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, view := range []string{"default", "internal"} {
+		if _, err := sim.Add("zone_auth", map[string]interface{}{"fqdn": "example.com", "view": view}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := objMgr.CreateARecord("default", view, "www.example.com", "", "10.0.0.1", ibclient.EA{"Site": view}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := objMgr.CreateARecord("default", "default", "mail.example.com", "", "10.0.0.2", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreatePTRRecord("default", "internal", "www.example.com", "", "10.0.0.1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateCNAMERecord("www.example.com", "web.example.com", "default", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if recs, err := objMgr.GetARecords("", "www.example.com", "", "", nil); err != nil || len(recs) != 2 {
+		t.Errorf("expected a record in each view, got %+v, error %v", recs, err)
+	}
+	if recs, err := objMgr.GetARecords("", "", "", "", ibclient.EA{"Site": "internal"}); err != nil || len(recs) != 1 || recs[0].View != "internal" {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+	if recs, err := objMgr.GetARecords("default", "", "", "example.com", nil); err != nil || len(recs) != 2 {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+	if recs, err := objMgr.GetCNAMERecords("", "", "www.example.com", "", nil); err != nil || len(recs) != 1 || recs[0].Name != "web.example.com" {
+		t.Errorf("unexpected records %+v, error %v", recs, err)
+	}
+
+	recsA, recsPTR, err := objMgr.GetRecordsByIP("10.0.0.1")
+	if err != nil || len(recsA) != 2 || len(recsPTR) != 1 || recsPTR[0].View != "internal" {
+		t.Errorf("unexpected records %+v and %+v, error %v", recsA, recsPTR, err)
+	}
+	if _, _, err := objMgr.GetRecordsByIP("2001:db8::1"); err == nil {
+		t.Error("expected an error for an IPv6 address")
+	}
+}
+
+func TestSimulatorHostRecordAddresses(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	host, err := objMgr.CreateHostRecord(false, "vm.example.com", "default", "default", "10.0.0.0/24", "", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	host, err = objMgr.AddHostRecordIPv4Addrs(host.Ref, []ibclient.HostRecordIpv4Addr{
+		{Ipv4Addr: "func:nextavailableip:10.0.0.0/24,default"},
+		{Ipv4Addr: "10.0.0.10", Mac: "00:11:22:33:44:55"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	host, err = objMgr.AddHostRecordIPv6Addrs(host.Ref, []ibclient.HostRecordIpv6Addr{{Ipv6Addr: "2001:db8::1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addrs, err := objMgr.GetIPAddressesFromHostRecord(*host); err != nil || len(addrs) != 4 || addrs[1] != "10.0.0.2" || addrs[3] != "2001:db8::1" {
+		t.Errorf("unexpected addresses %v, error %v", addrs, err)
+	}
+
+	if host, err = objMgr.RemoveHostRecordIPv4Addrs(host.Ref, []string{"10.0.0.1", "10.0.0.2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host, err = objMgr.RemoveHostRecordIPv6Addrs(host.Ref, []string{"2001:db8::1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(host.Ipv4Addrs) != 1 || host.Ipv4Addrs[0].Ipv4Addr != "10.0.0.10" || len(host.Ipv6Addrs) != 0 {
+		t.Fatalf("unexpected host %+v", host)
+	}
+
+	if host, err = objMgr.AddHostRecordAliases(host.Ref, []string{"a.example.com", "b.example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host, err = objMgr.RemoveHostRecordAliases(host.Ref, []string{"a.example.com"}); err != nil || len(host.Aliases) != 1 || host.Aliases[0] != "b.example.com" {
+		t.Errorf("unexpected host %+v, error %v", host, err)
+	}
+
+	options := []ibclient.DhcpOption{{Name: "routers", Num: 3, Value: "10.0.0.254"}}
+	addr, err := objMgr.UpdateHostRecordIPv4Addr(host.Ipv4Addrs[0].Ref, "", ibclient.BoolPtr(true), options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !*addr.ConfigureForDHCP || addr.Mac != "00:11:22:33:44:55" || addr.Host != "vm.example.com" ||
+		len(addr.Options) != 1 || addr.Options[0].Value != "10.0.0.254" {
+		t.Errorf("unexpected address %+v", addr)
+	}
+	if addr, err = objMgr.UpdateHostRecordIPv4Addr(addr.Ref, "00:11:22:33:44:66", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !*addr.ConfigureForDHCP || addr.Mac != "00:11:22:33:44:66" || len(addr.Options) != 1 {
+		t.Errorf("unexpected address %+v", addr)
+	}
+	if addr, err = objMgr.UpdateHostRecordIPv4Addr(addr.Ref, "", nil, []ibclient.DhcpOption{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !*addr.ConfigureForDHCP || len(addr.Options) != 0 {
+		t.Errorf("unexpected address %+v", addr)
+	}
+
+	if _, err := objMgr.RemoveHostRecordIPv4Addrs(host.Ref, []string{"10.0.0.10"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.GetIPAddressFromHostRecord(*host); err == nil {
+		t.Error("expected an error for a host record without IPv4 address")
+	}
+}
+
+func TestSimulatorRange(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	network, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rng, err := objMgr.CreateRangeInNetwork(network, 50, ibclient.ServerAssociationMember, "infoblox.localdomain", ibclient.EA{"Site": "lab"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rng, err = objMgr.GetRangeByRef(rng.Ref); err != nil || rng.StartAddr != "10.0.0.128" || rng.EndAddr != "10.0.0.254" ||
+		rng.Cidr != "10.0.0.0/24" || rng.ServerAssociationType != "MEMBER" || rng.Member.Name != "infoblox.localdomain" {
+		t.Fatalf("unexpected range %+v, error %v", rng, err)
+	}
+
+	exclude := []ibclient.ExclusionRange{{StartAddress: "10.0.0.200", EndAddress: "10.0.0.209"}}
+	routers := ibclient.DhcpOption{Name: "routers", Num: 3, Value: "10.0.0.254"}
+	if rng, err = objMgr.UpdateRange(rng.Ref, "10.0.0.100", "", ibclient.ServerAssociationFailover, "dhcp-failover", exclude, nil, routers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ranges, err := objMgr.GetRanges("default", "10.0.0.0/24", ibclient.EA{"Site": "lab"})
+	if err != nil || len(ranges) != 1 || ranges[0].StartAddr != "10.0.0.100" || ranges[0].FailoverAssociation != "dhcp-failover" ||
+		len(ranges[0].Exclude) != 1 || ranges[0].Exclude[0] != exclude[0] || len(ranges[0].Options) != 1 {
+		t.Errorf("unexpected ranges %+v, error %v", ranges, err)
+	}
+	if _, err = objMgr.UpdateRange(rng.Ref, "", "", "", "", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rng, err = objMgr.GetRangeByRef(rng.Ref); err != nil || len(rng.Exclude) != 1 || len(rng.Options) != 1 {
+		t.Errorf("unexpected range %+v, error %v", rng, err)
+	}
+	if _, err = objMgr.UpdateRange(rng.Ref, "", "", "", "", []ibclient.ExclusionRange{}, nil, []ibclient.DhcpOption{}...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rng, err = objMgr.GetRangeByRef(rng.Ref); err != nil || len(rng.Exclude) != 0 || len(rng.Options) != 0 {
+		t.Errorf("unexpected range %+v, error %v", rng, err)
+	}
+
+	if _, err := objMgr.CreateRange("default", "10.0.0.0/24", "10.0.0.10", "10.0.1.10", "", "", nil, nil); err == nil {
+		t.Error("expected an error for a range outside of the network")
+	}
+	if _, err := objMgr.CreateRange("default", "10.0.0.0/24", "10.0.0.10", "10.0.0.20", "DHCP", "", nil, nil); err == nil {
+		t.Error("expected an error for an unknown server association type")
+	}
+
+	if _, err := objMgr.DeleteRange(rng.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ranges, err := objMgr.SearchRanges(ibclient.NewQuery()); err != nil || len(ranges) != 0 {
+		t.Errorf("unexpected ranges %+v, error %v", ranges, err)
+	}
+}
+
+func TestSimulatorDhcpOptions(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	routers := ibclient.DhcpOption{Name: "routers", Num: 3, Value: "10.0.0.254"}
+	network, err := objMgr.CreateNetworkWithOptions("default", "10.0.0.0/24", "web", []ibclient.DhcpOption{routers})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dns := ibclient.DhcpOption{Name: "domain-name-servers", Num: 6, Value: "10.0.0.53"}
+	if _, err := objMgr.UpdateNetworkOptions(network.Ref, []ibclient.DhcpOption{routers, dns}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if network, err = objMgr.GetNetwork("default", "10.0.0.0/24", nil); err != nil || len(network.Options) != 2 || network.Options[1] != dns {
+		t.Errorf("unexpected network %+v, error %v", network, err)
+	}
+
+	fixedAddr, err := objMgr.AllocateIPWithOptions("default", "10.0.0.0/24", "", "", "vm", nil, []ibclient.DhcpOption{{Name: "host-name", Num: 12, Value: "vm"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	domain := ibclient.DhcpOption{Name: "domain-name", Num: 15, Value: "example.com"}
+	if _, err := objMgr.UpdateFixedAddressWithOptions(fixedAddr.Ref, "", "", "", "", []ibclient.DhcpOption{domain}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fixedAddr, err = objMgr.GetFixedAddressByRef(fixedAddr.Ref); err != nil || len(fixedAddr.Options) != 1 || fixedAddr.Options[0] != domain {
+		t.Errorf("unexpected fixed address %+v, error %v", fixedAddr, err)
+	}
+	if _, err := objMgr.UpdateFixedAddressWithOptions(fixedAddr.Ref, "", "", "", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fixedAddr, err = objMgr.GetFixedAddressByRef(fixedAddr.Ref); err != nil || len(fixedAddr.Options) != 1 {
+		t.Errorf("unexpected fixed address %+v, error %v", fixedAddr, err)
+	}
+	if _, err := objMgr.UpdateFixedAddressWithOptions(fixedAddr.Ref, "", "", "", "", []ibclient.DhcpOption{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fixedAddr, err = objMgr.GetFixedAddressByRef(fixedAddr.Ref); err != nil || len(fixedAddr.Options) != 0 {
+		t.Errorf("unexpected fixed address %+v, error %v", fixedAddr, err)
+	}
+
+	if _, err := objMgr.UpdateNetworkOptions(network.Ref, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if network, err = objMgr.GetNetwork("default", "10.0.0.0/24", nil); err != nil || len(network.Options) != 0 {
+		t.Errorf("unexpected network %+v, error %v", network, err)
+	}
+
+	if _, err := objMgr.CreateDhcpOptionSpace("acme", "ACME phones"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	space, err := objMgr.GetDhcpOptionSpace("acme")
+	if err != nil || space == nil || space.Comment != "ACME phones" {
+		t.Fatalf("unexpected option space %+v, error %v", space, err)
+	}
+	def, err := objMgr.CreateDhcpOptionDefinition("acme", "provisioning-server", 1, "string")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateDhcpOptionDefinition("acme", "tftp-server", 1, "ip-address"); err == nil {
+		t.Error("expected an error for a duplicate option code")
+	}
+	if _, err := objMgr.UpdateDhcpOptionDefinition(def.Ref, "", 0, "ip-address"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defs, err := objMgr.GetDhcpOptionDefinitions("acme", "")
+	if err != nil || len(defs) != 1 || defs[0].Type != "ip-address" || defs[0].Code != 1 {
+		t.Errorf("unexpected option definitions %+v, error %v", defs, err)
+	}
+
+	vendor := ibclient.DhcpOption{Name: "acme.provisioning-server", Value: "10.0.0.5", VendorClass: "acme"}
+	rng, err := objMgr.CreateRange("default", "10.0.0.0/24", "10.0.0.100", "10.0.0.200", "", "", nil, nil, vendor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rng, err = objMgr.GetRangeByRef(rng.Ref); err != nil || len(rng.Options) != 1 || rng.Options[0] != vendor {
+		t.Errorf("unexpected range %+v, error %v", rng, err)
+	}
+
+	if _, err := objMgr.DeleteDhcpOptionDefinition(def.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.DeleteDhcpOptionSpace(space.Ref); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSimulatorLeases(t *testing.T) {
+	sim, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, lease := range []map[string]interface{}{
+		{"address": "10.0.0.10", "hardware": "00:00:00:00:00:0a", "client_hostname": "fixed", "binding_state": "ACTIVE"},
+		{"address": "10.0.0.11", "hardware": "00:00:00:00:00:0b", "client_hostname": "host", "binding_state": "ACTIVE"},
+		{"address": "10.0.0.12", "hardware": "00:00:00:00:00:0c", "client_hostname": "rogue", "binding_state": "ACTIVE"},
+		{"address": "10.0.0.13", "hardware": "00:00:00:00:00:0d", "client_hostname": "gone", "binding_state": "EXPIRED"},
+	} {
+		lease["starts"] = 1700000000 + i
+		if _, err := sim.Add("lease", lease); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := objMgr.AllocateIP("default", "10.0.0.0/24", "10.0.0.10", "00:00:00:00:00:0a", "fixed", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateHostRecord(false, "host.example.com", "default", "default", "10.0.0.0/24", "10.0.0.11", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	leases, err := objMgr.GetLeases("default", "10.0.0.0/24", "", "", "", ibclient.LeaseStateActive)
+	if err != nil || len(leases) != 3 {
+		t.Errorf("expected 3 active leases, got %+v, error %v", leases, err)
+	}
+	leases, err = objMgr.GetLeases("", "", "", "00:00:00:00:00:0c", "", "")
+	if err != nil || len(leases) != 1 || leases[0].ClientHostname != "rogue" || leases[0].Starts != 1700000002 {
+		t.Errorf("unexpected leases %+v, error %v", leases, err)
+	}
+
+	unreserved, err := objMgr.GetUnreservedLeases("default", "10.0.0.0/24")
+	if err != nil || len(unreserved) != 1 || unreserved[0].Address != "10.0.0.12" {
+		t.Errorf("expected the lease of 10.0.0.12 only, got %+v, error %v", unreserved, err)
+	}
+
+	if _, err := objMgr.CreateIPv6Network("default", "2001:db8::/64", "web6"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, addr := range []string{"2001:db8::10", "2001:db8::11", "2001:db8::12"} {
+		if _, err := sim.Add("lease", map[string]interface{}{"address": addr, "protocol": "IPV6", "binding_state": "ACTIVE"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := objMgr.AllocateIPv6("default", "2001:db8::/64", "2001:db8::10", "00:01:00:01:00:00:00:00:00:0a", "fixed", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	host, err := objMgr.CreateHostRecord(false, "host6.example.com", "default", "default", "10.0.0.0/24", "10.0.0.20", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.AddHostRecordIPv6Addrs(host.Ref, []ibclient.HostRecordIpv6Addr{{Ipv6Addr: "2001:db8::11"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unreserved, err = objMgr.GetUnreservedLeases("default", "2001:db8::/64")
+	if err != nil || len(unreserved) != 1 || unreserved[0].Address != "2001:db8::12" {
+		t.Errorf("expected the lease of 2001:db8::12 only, got %+v, error %v", unreserved, err)
+	}
+}
+
+func TestSimulatorIPAddresses(t *testing.T) {
+	sim, _, objMgr := newTestObjectManager(t)
+
+	if _, err := objMgr.CreateNetwork("default", "10.0.0.0/29", "web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.AllocateIP("default", "10.0.0.0/29", "10.0.0.1", "00:00:00:00:00:01", "fixed", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateHostRecord(false, "host.example.com", "default", "default", "10.0.0.0/29", "10.0.0.2", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := sim.Add("lease", map[string]interface{}{
+		"address": "10.0.0.3", "hardware": "00:00:00:00:00:03", "binding_state": "ACTIVE"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := sim.Add("lease", map[string]interface{}{
+		"address": "10.0.0.1", "hardware": "00:00:00:00:00:ff", "binding_state": "ACTIVE"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	used, err := objMgr.GetIPv4Addresses("default", "10.0.0.0/29", ibclient.IPAddressStatusUsed)
+	if err != nil || len(used) != 5 {
+		t.Fatalf("expected 5 used addresses, got %+v, error %v", used, err)
+	}
+	unused, err := objMgr.GetIPv4Addresses("default", "10.0.0.0/29", ibclient.IPAddressStatusUnused)
+	if err != nil || len(unused) != 3 || unused[0].IPAddress != "10.0.0.4" {
+		t.Errorf("expected 3 unused addresses from 10.0.0.4, got %+v, error %v", unused, err)
+	}
+
+	addr, err := objMgr.GetIPv4Address("default", "10.0.0.1")
+	if err != nil || addr == nil {
+		t.Fatalf("unexpected address %+v, error %v", addr, err)
+	}
+	if !reflect.DeepEqual(addr.Types, []string{ibclient.IPAddressTypeFixedAddress, ibclient.IPAddressTypeLease}) ||
+		len(addr.Objects) != 2 || !addr.IsConflict || addr.MacAddress != "00:00:00:00:00:01" {
+		t.Errorf("expected a fixed address conflicting with a lease, got %+v", addr)
+	}
+	if _, ok := sim.Object(addr.Objects[0]); !ok {
+		t.Errorf("expected %s to be the fixed address", addr.Objects[0])
+	}
+	addr, err = objMgr.GetIPv4Address("default", "10.0.0.2")
+	if err != nil || addr == nil || !reflect.DeepEqual(addr.Types, []string{ibclient.IPAddressTypeHost}) ||
+		!reflect.DeepEqual(addr.Names, []string{"host.example.com"}) {
+		t.Errorf("expected a host address, got %+v, error %v", addr, err)
+	}
+
+	for ip, free := range map[string]bool{"10.0.0.0": false, "10.0.0.3": false, "10.0.0.4": true, "10.0.0.7": false} {
+		if ok, err := objMgr.IsIPAddressFree("default", ip); err != nil || ok != free {
+			t.Errorf("expected %s free to be %v, got %v, error %v", ip, free, ok, err)
+		}
+	}
+	if _, err := objMgr.IsIPAddressFree("default", "10.1.0.1"); err == nil {
+		t.Errorf("expected an error for an address outside the networks")
+	}
+
+	if _, err := objMgr.CreateIPv6Network("default", "2001:db8::/64", "v6"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := sim.Add("ipv6fixedaddress", map[string]interface{}{
+		"ipv6addr": "2001:db8::10", "duid": "00:01", "network_view": "default"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v6, err := objMgr.GetIPv6Addresses("default", "2001:db8::/64", "")
+	if err != nil || len(v6) != 1 || v6[0].IPAddress != "2001:db8::10" || v6[0].Status != ibclient.IPAddressStatusUsed {
+		t.Errorf("expected the used IPv6 address, got %+v, error %v", v6, err)
+	}
+	if ok, err := objMgr.IsIPAddressFree("default", "2001:db8::11"); err != nil || !ok {
+		t.Errorf("expected 2001:db8::11 to be free, got %v, error %v", ok, err)
+	}
+
+	if _, err := objMgr.SearchIPv4Addresses(ibclient.NewQuery().Equal("status", ibclient.IPAddressStatusUsed)); err == nil {
+		t.Errorf("expected an error searching addresses without a network")
+	}
+}
+
+func TestSimulatorNextAvailableFunctions(t *testing.T) {
+	_, _, objMgr := newTestObjectManager(t)
+
+	network, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.AllocateIP("default", "10.0.0.0/24", "10.0.0.1", "", "used", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ips, err := objMgr.GetNextAvailableIPs(network.Ref, 3, []string{"10.0.0.2"})
+	if err != nil || !reflect.DeepEqual(ips, []string{"10.0.0.3", "10.0.0.4", "10.0.0.5"}) {
+		t.Errorf("unexpected addresses %v, error %v", ips, err)
+	}
+
+	r, err := objMgr.CreateRange("default", "10.0.0.0/24", "10.0.0.200", "10.0.0.201", ibclient.ServerAssociationNone, "", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ips, err = objMgr.GetNextAvailableIPs(r.Ref, 2, nil)
+	if err != nil || !reflect.DeepEqual(ips, []string{"10.0.0.200", "10.0.0.201"}) {
+		t.Errorf("unexpected range addresses %v, error %v", ips, err)
+	}
+	if _, err := objMgr.GetNextAvailableIPs(r.Ref, 3, nil); err == nil {
+		t.Errorf("expected an error asking more addresses than the range has")
+	}
+
+	container, err := objMgr.CreateNetworkContainer("default", "10.1.0.0/24")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := objMgr.CreateNetwork("default", "10.1.0.0/26", "taken"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	networks, err := objMgr.GetNextAvailableNetworks(container.Ref, 26, 2, []string{"10.1.0.64/26"})
+	if err != nil || !reflect.DeepEqual(networks, []string{"10.1.0.128/26", "10.1.0.192/26"}) {
+		t.Errorf("unexpected networks %v, error %v", networks, err)
+	}
+
+	if _, err := objMgr.GetNextAvailableIPs(container.Ref, 1, nil); err == nil {
+		t.Errorf("expected an error calling next_available_ip on a network container")
+	}
+}
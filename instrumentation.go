@@ -0,0 +1,155 @@
+package ibclient
+
+import (
+	"context"
+	"time"
+)
+
+// RequestInfo describes a single WAPI request sent by a Connector
+type RequestInfo struct {
+	Method     string
+	ObjectType string
+	// URL is the request URL with credentials redacted
+	URL string
+	// ProxySearch is true if the request was sent with _proxy_search=GM,
+	// either always or as a fallback after the member failed
+	ProxySearch bool
+
+	// the fields below are only set when the request is finished
+
+	// StatusCode is the HTTP status of the response, 0 if none was received
+	StatusCode int
+	// Retries is the number of times the requestor re-sent the request
+	Retries  int
+	Duration time.Duration
+	Err      error
+}
+
+// Instrumentation is the interface implemented by metrics and tracing hooks
+// which are called around every WAPI request sent by a Connector
+type Instrumentation interface {
+	// RequestStarted is called before the request is sent. The returned
+	// context is used to send the request and passed to RequestFinished.
+	RequestStarted(ctx context.Context, info *RequestInfo) context.Context
+	// RequestFinished is called once the request completed or failed
+	RequestFinished(ctx context.Context, info *RequestInfo)
+}
+
+type instrumentationChain []Instrumentation
+
+// ChainInstrumentation combines several Instrumentation hooks into one.
+// RequestStarted is called in order, RequestFinished in reverse order.
+func ChainInstrumentation(hooks ...Instrumentation) Instrumentation {
+	return instrumentationChain(hooks)
+}
+
+func (c instrumentationChain) RequestStarted(ctx context.Context, info *RequestInfo) context.Context {
+	for _, h := range c {
+		ctx = h.RequestStarted(ctx, info)
+	}
+	return ctx
+}
+
+func (c instrumentationChain) RequestFinished(ctx context.Context, info *RequestInfo) {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i].RequestFinished(ctx, info)
+	}
+}
+
+// requestStats is filled in by the WapiHTTPRequestor with details the
+// HTTPRequestor interface does not return
+type requestStats struct {
+	statusCode int
+	retries    int
+}
+
+type requestStatsKey struct{}
+
+func withRequestStats(ctx context.Context) (context.Context, *requestStats) {
+	stats := &requestStats{}
+	return context.WithValue(ctx, requestStatsKey{}, stats), stats
+}
+
+// requestStatsFrom returns the requestStats of ctx, or a throw-away one if
+// the request was not sent by a Connector
+func requestStatsFrom(ctx context.Context) *requestStats {
+	if stats, ok := ctx.Value(requestStatsKey{}).(*requestStats); ok {
+		return stats
+	}
+	return &requestStats{}
+}
+
+// Span is the subset of an OpenTelemetry span used by TracingInstrumentation
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Tracer is the subset of an OpenTelemetry tracer used by
+// TracingInstrumentation. An OpenTelemetry trace.Tracer is adapted with a
+// few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//	type otelSpan struct{ trace.Span }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, ibclient.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+//	func (s otelSpan) SetAttribute(key string, value interface{}) {
+//		s.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+//	}
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.Span.RecordError(err)
+//		s.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End() { s.Span.End() }
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// TracingInstrumentation is an Instrumentation creating a client span for
+// every WAPI request, with attributes following the OpenTelemetry HTTP
+// semantic conventions
+type TracingInstrumentation struct {
+	tracer Tracer
+}
+
+// NewTracingInstrumentation returns a TracingInstrumentation starting spans
+// with tracer
+func NewTracingInstrumentation(tracer Tracer) *TracingInstrumentation {
+	return &TracingInstrumentation{tracer: tracer}
+}
+
+type spanKey struct{}
+
+// RequestStarted implements Instrumentation
+func (ti *TracingInstrumentation) RequestStarted(ctx context.Context, info *RequestInfo) context.Context {
+	ctx, span := ti.tracer.Start(ctx, "WAPI "+info.Method+" "+info.ObjectType)
+	span.SetAttribute("http.request.method", info.Method)
+	span.SetAttribute("url.full", info.URL)
+	span.SetAttribute("infoblox.wapi.object_type", info.ObjectType)
+	span.SetAttribute("infoblox.wapi.proxy_search", info.ProxySearch)
+
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// RequestFinished implements Instrumentation
+func (ti *TracingInstrumentation) RequestFinished(ctx context.Context, info *RequestInfo) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return
+	}
+	if info.StatusCode != 0 {
+		span.SetAttribute("http.response.status_code", info.StatusCode)
+	}
+	span.SetAttribute("infoblox.wapi.retries", info.Retries)
+	if info.Err != nil {
+		span.RecordError(info.Err)
+	}
+	span.End()
+}
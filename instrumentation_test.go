@@ -0,0 +1,91 @@
+package ibclient
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *fakeSpan) RecordError(err error)                      { s.err = err }
+func (s *fakeSpan) End()                                       { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &fakeSpan{name: name, attrs: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestTracingInstrumentation(t *testing.T) {
+	var calls int32
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"_ref": "network/ZG5z:10.0.0.0/24/default", "network": "10.0.0.0/24"}]`))
+	})
+	policy := NewBackoffRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	conn.Requestor = newTestRequestor(policy)
+	tracer := &fakeTracer{}
+	conn.Instrumentation = NewTracingInstrumentation(tracer)
+
+	var res []Network
+	if err := conn.GetObject(NewNetwork(Network{NetviewName: "default"}), "", &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected a single span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "WAPI GET network" || !span.ended || span.err != nil {
+		t.Errorf("unexpected span %+v", span)
+	}
+	if span.attrs["http.response.status_code"] != http.StatusOK || span.attrs["infoblox.wapi.retries"] != 1 {
+		t.Errorf("unexpected span attributes %v", span.attrs)
+	}
+}
+
+type orderRecorder struct {
+	name  string
+	order *[]string
+}
+
+func (o orderRecorder) RequestStarted(ctx context.Context, info *RequestInfo) context.Context {
+	*o.order = append(*o.order, "start "+o.name)
+	return ctx
+}
+
+func (o orderRecorder) RequestFinished(ctx context.Context, info *RequestInfo) {
+	*o.order = append(*o.order, "finish "+o.name)
+}
+
+func TestChainInstrumentation(t *testing.T) {
+	var order []string
+	chain := ChainInstrumentation(orderRecorder{"a", &order}, orderRecorder{"b", &order})
+
+	ctx := chain.RequestStarted(context.Background(), &RequestInfo{})
+	chain.RequestFinished(ctx, &RequestInfo{})
+
+	expected := []string{"start a", "start b", "finish b", "finish a"}
+	for i := range expected {
+		if i >= len(order) || order[i] != expected[i] {
+			t.Fatalf("got %v, expected %v", order, expected)
+		}
+	}
+}
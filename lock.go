@@ -1,6 +1,7 @@
 package ibclient
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -16,6 +17,8 @@ const (
 type Lock interface {
 	Lock() error
 	UnLock(force bool) error
+	LockWithContext(ctx context.Context) error
+	UnLockWithContext(ctx context.Context, force bool) error
 }
 
 // NetworkViewLock contains lock data
@@ -24,6 +27,15 @@ type NetworkViewLock struct {
 	ObjMgr        *ObjectManager
 	LockEA        string
 	LockTimeoutEA string
+	// Logger receives the lock progress, the Logger of ObjMgr is used if nil
+	Logger Logger
+}
+
+func (l *NetworkViewLock) logger() Logger {
+	if l.Logger == nil && l.ObjMgr != nil {
+		return loggerOrNop(l.ObjMgr.Logger)
+	}
+	return loggerOrNop(l.Logger)
 }
 
 func (l *NetworkViewLock) createLockRequest() *MultiRequest {
@@ -148,36 +160,40 @@ func (l *NetworkViewLock) createUnlockRequest(force bool) *MultiRequest {
 	return req
 }
 
-func (l *NetworkViewLock) getLock() bool {
-	// logrus.Debugf("Creating lock on network niew %s\n", l.Name)
+func (l *NetworkViewLock) getLock(ctx context.Context) bool {
+	l.logger().Debug("Creating lock on network view", "network_view", l.Name)
 	req := l.createLockRequest()
-	res, err := l.ObjMgr.CreateMultiObject(req)
+	res, err := l.ObjMgr.CreateMultiObjectWithContext(ctx, req)
 
 	if err != nil {
-		// logrus.Debugf("Failed to create lock on network view %s: %s\n", l.Name, err)
+		l.logger().Debug("Failed to create lock on network view", "network_view", l.Name, "error", err)
 
 		//Check for Lock Timeout
-		nw, err := l.ObjMgr.GetNetworkView(l.Name)
+		nw, err := l.ObjMgr.GetNetworkViewWithContext(ctx, l.Name)
 		if err != nil {
-			// logrus.Debugf("Failed to get the network view object for %s : %s\n", l.Name, err)
+			l.logger().Debug("Failed to get the network view object", "network_view", l.Name, "error", err)
 			return false
 		}
 
 		if t, ok := nw.Ea[l.LockTimeoutEA]; ok {
 			if int32(time.Now().Unix())-int32(t.(int)) > timeout {
-				// logrus.Debugln("Lock is timed out. Forcefully acquiring it.")
+				l.logger().Debug("Lock is timed out. Forcefully acquiring it.", "network_view", l.Name)
 				//remove the lock forcefully, ignoring errors, and acquire it
-				_ = l.UnLock(true)
+				_ = l.UnLockWithContext(ctx, true)
 				// try to get lock again
-				return l.getLock()
+				return l.getLock(ctx)
 			}
 		}
 		return false
 	}
 
+	if len(res) == 0 {
+		l.logger().Debug("No lock state returned for network view", "network_view", l.Name)
+		return false
+	}
+
 	dockerID := res[0]["DOCKER-ID"]
 	if dockerID == l.ObjMgr.tenantID {
-		// logrus.Debugln("Got the lock !!!")
 		return true
 	}
 
@@ -187,17 +203,22 @@ func (l *NetworkViewLock) getLock() bool {
 // Lock attempts to lock on a network view. Lock will retry up to 10 times with
 // a random delay, between 1 and 10 seconds, between each attempt.
 func (l *NetworkViewLock) Lock() error {
+	return l.LockWithContext(context.Background())
+}
+
+// LockWithContext is like Lock but gives up as soon as ctx is done, including
+// while waiting between attempts.
+func (l *NetworkViewLock) LockWithContext(ctx context.Context) error {
 
 	// verify if network view exists and has EA for the lock
-	nw, err := l.ObjMgr.GetNetworkView(l.Name)
+	nw, err := l.ObjMgr.GetNetworkViewWithContext(ctx, l.Name)
 	if err != nil {
-		msg := fmt.Sprintf("Failed to get the network view object for %s : %s\n", l.Name, err)
-		// logrus.Debugf(msg)
-		return fmt.Errorf(msg)
+		l.logger().Error("Failed to get the network view object", "network_view", l.Name, "error", err)
+		return fmt.Errorf("Failed to get the network view object for %s : %s", l.Name, err)
 	}
 
 	if _, ok := nw.Ea[l.LockEA]; !ok {
-		err = l.ObjMgr.UpdateNetworkViewEA(nw.Ref, EA{l.LockEA: freeLockVal}, nil)
+		err = l.ObjMgr.UpdateNetworkViewEAWithContext(ctx, nw.Ref, EA{l.LockEA: freeLockVal}, nil)
 		if err != nil {
 			return fmt.Errorf("Failed to Update Network view with Lock EA")
 		}
@@ -206,46 +227,59 @@ func (l *NetworkViewLock) Lock() error {
 	retryCount := 0
 	for {
 		// Get lock on the network view
-		lock := l.getLock()
+		lock := l.getLock(ctx)
 		if lock {
-			// Got the lock.
-			// logrus.Debugf("Got the lock on Network View %s\n", l.Name)
+			l.logger().Debug("Got the lock on network view", "network_view", l.Name)
 			return nil
 		}
 
 		// Lock is held by some other agent. Wait for some time and retry it again
 		if retryCount >= 10 {
+			l.logger().Error("Failed to get lock on network view", "network_view", l.Name, "attempts", retryCount+1)
 			return fmt.Errorf("Failed to get Lock on Network View %s", l.Name)
 		}
 
 		retryCount++
-		// logrus.Debugf("Lock on Network View %s not free. Retrying again %d out of 10.\n", l.Name, retryCount)
+		l.logger().Debug("Lock on network view not free, retrying", "network_view", l.Name, "retry", retryCount, "max_retries", 10)
 		// sleep for random time (between 1 - 10 seconds) to reduce collisions
-		time.Sleep(time.Duration(rand.Intn(9)+1) * time.Second)
-		continue
+		delay := time.NewTimer(time.Duration(rand.Intn(9)+1) * time.Second)
+		select {
+		case <-ctx.Done():
+			delay.Stop()
+			return fmt.Errorf("Failed to get Lock on Network View %s: %w", l.Name, ctx.Err())
+		case <-delay.C:
+		}
 	}
 }
 
 // UnLock attempts to release a lock on a network view.
 func (l *NetworkViewLock) UnLock(force bool) error {
+	return l.UnLockWithContext(context.Background(), force)
+}
+
+// UnLockWithContext is like UnLock but the WAPI request is bound to ctx.
+func (l *NetworkViewLock) UnLockWithContext(ctx context.Context, force bool) error {
 	// To unlock set the Docker-Plugin-Lock EA of network view to Available and
 	// remove the Docker-Plugin-Lock-Time EA
 	req := l.createUnlockRequest(force)
-	res, err := l.ObjMgr.CreateMultiObject(req)
+	res, err := l.ObjMgr.CreateMultiObjectWithContext(ctx, req)
 
 	if err != nil {
-		msg := fmt.Sprintf("Failed to release lock from Network View %s: %s\n", l.Name, err)
-		// logrus.Errorf(msg)
-		return fmt.Errorf(msg)
+		l.logger().Error("Failed to release lock from network view", "network_view", l.Name, "error", err)
+		return fmt.Errorf("Failed to release lock from Network View %s: %s", l.Name, err)
+	}
+
+	if len(res) == 0 {
+		l.logger().Error("No lock state returned for network view", "network_view", l.Name)
+		return fmt.Errorf("Failed to release lock from Network View %s: no lock state returned", l.Name)
 	}
 
 	dockerID := res[0]["DOCKER-ID"]
 	if dockerID == freeLockVal {
-		// logrus.Debugln("Removed the lock!")
+		l.logger().Debug("Removed the lock from network view", "network_view", l.Name)
 		return nil
 	}
 
-	msg := fmt.Sprintf("Failed to release lock from Network View %s\n", l.Name)
-	// logrus.Errorf(msg)
-	return fmt.Errorf(msg)
+	l.logger().Error("Failed to release lock from network view", "network_view", l.Name, "lock_owner", dockerID)
+	return fmt.Errorf("Failed to release lock from Network View %s", l.Name)
 }
@@ -0,0 +1,138 @@
+package ibclient
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+// Logger is the interface implemented by leveled, structured loggers. The
+// keysAndValues arguments alternate between string keys and their values,
+// e.g. logger.Error("WAPI request failed", "method", "GET", "status", 400).
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// LogLevel is the minimum level of the messages written by a StdLogger
+type LogLevel int
+
+const (
+	// LevelDebug writes debug, info and error messages
+	LevelDebug LogLevel = iota
+	// LevelInfo writes info and error messages
+	LevelInfo
+	// LevelError writes error messages only
+	LevelError
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// NopLogger discards every message, it is used wherever no Logger is set
+var NopLogger Logger = nopLogger{}
+
+func loggerOrNop(l Logger) Logger {
+	if l == nil {
+		return NopLogger
+	}
+	return l
+}
+
+// StdLogger adapts a standard library *log.Logger to the Logger interface,
+// writing messages as "level=error msg=\"...\" key=value ..."
+type StdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger returns a StdLogger writing messages of at least the given
+// level to l, or to the standard logger if l is nil
+func NewStdLogger(l *log.Logger, level LogLevel) *StdLogger {
+	if l == nil {
+		l = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+	return &StdLogger{logger: l, level: level}
+}
+
+// Debug implements Logger
+func (l *StdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(LevelDebug, "debug", msg, keysAndValues)
+}
+
+// Info implements Logger
+func (l *StdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(LevelInfo, "info", msg, keysAndValues)
+}
+
+// Error implements Logger
+func (l *StdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(LevelError, "error", msg, keysAndValues)
+}
+
+func (l *StdLogger) log(level LogLevel, levelName string, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "level=%s msg=%q", levelName, msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprintf("%v", keysAndValues[i])
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keysAndValues) {
+			value = redactValue(key, keysAndValues[i+1])
+		}
+		fmt.Fprintf(&sb, " %s=%q", key, fmt.Sprintf("%v", value))
+	}
+	l.logger.Print(sb.String())
+}
+
+// sensitiveKeys are log keys whose values are never written
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "ibapauth"}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactValue(key string, value interface{}) interface{} {
+	if isSensitiveKey(key) {
+		return "REDACTED"
+	}
+	return value
+}
+
+// redactURL returns u as a string with any user information and sensitive
+// query arguments masked
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+	if redacted.RawQuery != "" {
+		vals := redacted.Query()
+		for k := range vals {
+			if isSensitiveKey(k) {
+				vals.Set(k, "REDACTED")
+			}
+		}
+		redacted.RawQuery = vals.Encode()
+	}
+
+	return redacted.String()
+}
@@ -0,0 +1,171 @@
+package ibclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the request
+// latency histogram buckets used when none are given
+var DefaultLatencyBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type metricLabels struct {
+	method      string
+	objectType  string
+	status      string
+	proxySearch bool
+}
+
+type requestMetrics struct {
+	requests     uint64
+	errors       uint64
+	retries      uint64
+	latencySum   float64
+	latencyCount []uint64 // per bucket, not cumulative
+}
+
+// MetricsCollector is an Instrumentation aggregating WAPI request counts,
+// errors, retries and latencies, labeled by method, object type, HTTP
+// status and Grid Master proxy usage. The metrics are exposed in the
+// Prometheus text format by WriteTo and ServeHTTP.
+type MetricsCollector struct {
+	buckets []float64
+
+	mu      sync.Mutex
+	metrics map[metricLabels]*requestMetrics
+}
+
+// NewMetricsCollector returns a MetricsCollector using the given latency
+// histogram buckets, in seconds, or DefaultLatencyBuckets if none are given
+func NewMetricsCollector(buckets ...float64) *MetricsCollector {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+
+	return &MetricsCollector{
+		buckets: sorted,
+		metrics: make(map[metricLabels]*requestMetrics),
+	}
+}
+
+// RequestStarted implements Instrumentation
+func (mc *MetricsCollector) RequestStarted(ctx context.Context, info *RequestInfo) context.Context {
+	return ctx
+}
+
+// RequestFinished implements Instrumentation
+func (mc *MetricsCollector) RequestFinished(ctx context.Context, info *RequestInfo) {
+	labels := metricLabels{
+		method:      info.Method,
+		objectType:  info.ObjectType,
+		status:      strconv.Itoa(info.StatusCode),
+		proxySearch: info.ProxySearch,
+	}
+	if info.StatusCode == 0 {
+		labels.status = "error"
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	m, ok := mc.metrics[labels]
+	if !ok {
+		m = &requestMetrics{latencyCount: make([]uint64, len(mc.buckets)+1)}
+		mc.metrics[labels] = m
+	}
+	m.requests++
+	if info.Err != nil {
+		m.errors++
+	}
+	m.retries += uint64(info.Retries)
+
+	secs := info.Duration.Seconds()
+	m.latencySum += secs
+	i := sort.SearchFloat64s(mc.buckets, secs)
+	m.latencyCount[i]++
+}
+
+// WriteTo writes the collected metrics to w in the Prometheus text format
+func (mc *MetricsCollector) WriteTo(w io.Writer) (int64, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	labels := make([]metricLabels, 0, len(mc.metrics))
+	for l := range mc.metrics {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return fmt.Sprint(labels[i]) < fmt.Sprint(labels[j])
+	})
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	counters := []struct {
+		name  string
+		help  string
+		value func(*requestMetrics) uint64
+	}{
+		{"infoblox_wapi_requests_total", "Number of WAPI requests.", func(m *requestMetrics) uint64 { return m.requests }},
+		{"infoblox_wapi_request_errors_total", "Number of failed WAPI requests.", func(m *requestMetrics) uint64 { return m.errors }},
+		{"infoblox_wapi_request_retries_total", "Number of WAPI request retries.", func(m *requestMetrics) uint64 { return m.retries }},
+	}
+	for _, c := range counters {
+		fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, l := range labels {
+			fmt.Fprintf(cw, "%s{%s} %d\n", c.name, l.format(), c.value(mc.metrics[l]))
+		}
+	}
+
+	name := "infoblox_wapi_request_duration_seconds"
+	fmt.Fprintf(cw, "# HELP %s Latency of WAPI requests.\n# TYPE %s histogram\n", name, name)
+	for _, l := range labels {
+		m := mc.metrics[l]
+		var cumulative uint64
+		for i, bound := range mc.buckets {
+			cumulative += m.latencyCount[i]
+			fmt.Fprintf(cw, "%s_bucket{%s,le=\"%s\"} %d\n", name, l.format(), strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(cw, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, l.format(), m.requests)
+		fmt.Fprintf(cw, "%s_sum{%s} %s\n", name, l.format(), strconv.FormatFloat(m.latencySum, 'g', -1, 64))
+		fmt.Fprintf(cw, "%s_count{%s} %d\n", name, l.format(), m.requests)
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the collected metrics in the Prometheus text format so
+// the collector can be mounted as a scrape endpoint
+func (mc *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = mc.WriteTo(w)
+}
+
+func (l metricLabels) format() string {
+	return fmt.Sprintf("method=%q,object_type=%q,status=%q,proxy_search=\"%t\"", l.method, l.objectType, l.status, l.proxySearch)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,62 @@
+package ibclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	mc := NewMetricsCollector(0.1, 1)
+	mc.RequestFinished(context.Background(), &RequestInfo{Method: "GET", ObjectType: "network", StatusCode: 200, Retries: 2, Duration: 50 * time.Millisecond})
+	mc.RequestFinished(context.Background(), &RequestInfo{Method: "GET", ObjectType: "network", StatusCode: 200, Duration: 500 * time.Millisecond})
+	mc.RequestFinished(context.Background(), &RequestInfo{Method: "POST", ObjectType: "record:a", ProxySearch: true, Err: http.ErrHandlerTimeout, Duration: 2 * time.Second})
+
+	var buf bytes.Buffer
+	if _, err := mc.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	getLabels := `method="GET",object_type="network",status="200",proxy_search="false"`
+	postLabels := `method="POST",object_type="record:a",status="error",proxy_search="true"`
+	for _, line := range []string{
+		`infoblox_wapi_requests_total{` + getLabels + `} 2`,
+		`infoblox_wapi_request_retries_total{` + getLabels + `} 2`,
+		`infoblox_wapi_request_errors_total{` + getLabels + `} 0`,
+		`infoblox_wapi_request_errors_total{` + postLabels + `} 1`,
+		`infoblox_wapi_request_duration_seconds_bucket{` + getLabels + `,le="0.1"} 1`,
+		`infoblox_wapi_request_duration_seconds_bucket{` + getLabels + `,le="1"} 2`,
+		`infoblox_wapi_request_duration_seconds_bucket{` + postLabels + `,le="1"} 0`,
+		`infoblox_wapi_request_duration_seconds_bucket{` + postLabels + `,le="+Inf"} 1`,
+		`infoblox_wapi_request_duration_seconds_count{` + getLabels + `} 2`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("missing %q in\n%s", line, out)
+		}
+	}
+}
+
+func TestMetricsCollectorWithConnector(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mc := NewMetricsCollector()
+	conn.Instrumentation = mc
+
+	var res []Network
+	if err := conn.GetObject(NewNetwork(Network{}), "", &res); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	rec := httptest.NewRecorder()
+	mc.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	expected := `infoblox_wapi_request_errors_total{method="GET",object_type="network",status="404",proxy_search="false"} 1`
+	if !strings.Contains(rec.Body.String(), expected) {
+		t.Errorf("missing %q in\n%s", expected, rec.Body.String())
+	}
+}
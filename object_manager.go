@@ -1,9 +1,12 @@
 package ibclient
 
 import (
+	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
 )
 
@@ -55,6 +58,8 @@ type ObjectManager struct {
 	tenantID  string
 	// If OmitCloudAttrs is true no extra attributes for cloud are set
 	OmitCloudAttrs bool
+	// Logger receives object manager errors, nothing is logged if nil
+	Logger Logger
 }
 
 // NewObjectManager returns an ObjectManager configured with ...
@@ -88,23 +93,28 @@ func (objMgr *ObjectManager) extendEA(ea EA) EA {
 
 // CreateNetworkView https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) CreateNetworkView(name string) (*NetworkView, error) {
+	return objMgr.CreateNetworkViewWithContext(context.Background(), name)
+}
+
+// CreateNetworkViewWithContext is like CreateNetworkView but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateNetworkViewWithContext(ctx context.Context, name string) (*NetworkView, error) {
 	networkView := NewNetworkView(NetworkView{
 		Name: name,
 		Ea:   objMgr.getBasicEA(false)})
 
-	ref, err := objMgr.connector.CreateObject(networkView)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, networkView)
 	networkView.Ref = ref
 
 	return networkView, err
 }
 
-func (objMgr *ObjectManager) makeNetworkView(netviewName string) (netviewRef string, err error) {
+func (objMgr *ObjectManager) makeNetworkView(ctx context.Context, netviewName string) (netviewRef string, err error) {
 	var netviewObj *NetworkView
-	if netviewObj, err = objMgr.GetNetworkView(netviewName); err != nil {
+	if netviewObj, err = objMgr.GetNetworkViewWithContext(ctx, netviewName); err != nil {
 		return
 	}
 	if netviewObj == nil {
-		if netviewObj, err = objMgr.CreateNetworkView(netviewName); err != nil {
+		if netviewObj, err = objMgr.CreateNetworkViewWithContext(ctx, netviewName); err != nil {
 			return
 		}
 	}
@@ -116,11 +126,16 @@ func (objMgr *ObjectManager) makeNetworkView(netviewName string) (netviewRef str
 
 // CreateDefaultNetviews https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) CreateDefaultNetviews(globalNetview string, localNetview string) (globalNetviewRef string, localNetviewRef string, err error) {
-	if globalNetviewRef, err = objMgr.makeNetworkView(globalNetview); err != nil {
+	return objMgr.CreateDefaultNetviewsWithContext(context.Background(), globalNetview, localNetview)
+}
+
+// CreateDefaultNetviewsWithContext is like CreateDefaultNetviews but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateDefaultNetviewsWithContext(ctx context.Context, globalNetview string, localNetview string) (globalNetviewRef string, localNetviewRef string, err error) {
+	if globalNetviewRef, err = objMgr.makeNetworkView(ctx, globalNetview); err != nil {
 		return
 	}
 
-	if localNetviewRef, err = objMgr.makeNetworkView(localNetview); err != nil {
+	if localNetviewRef, err = objMgr.makeNetworkView(ctx, localNetview); err != nil {
 		return
 	}
 
@@ -129,15 +144,32 @@ func (objMgr *ObjectManager) CreateDefaultNetviews(globalNetview string, localNe
 
 // CreateNetwork https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) CreateNetwork(netview string, cidr string, name string) (*Network, error) {
+	return objMgr.CreateNetworkWithContext(context.Background(), netview, cidr, name)
+}
+
+// CreateNetworkWithContext is like CreateNetwork but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateNetworkWithContext(ctx context.Context, netview string, cidr string, name string) (*Network, error) {
+	return objMgr.CreateNetworkWithOptionsWithContext(ctx, netview, cidr, name, nil)
+}
+
+// CreateNetworkWithOptions is like CreateNetwork but also sets the DHCP
+// options of the network
+func (objMgr *ObjectManager) CreateNetworkWithOptions(netview string, cidr string, name string, options []DhcpOption) (*Network, error) {
+	return objMgr.CreateNetworkWithOptionsWithContext(context.Background(), netview, cidr, name, options)
+}
+
+// CreateNetworkWithOptionsWithContext is like CreateNetworkWithOptions but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateNetworkWithOptionsWithContext(ctx context.Context, netview string, cidr string, name string, options []DhcpOption) (*Network, error) {
 	network := NewNetwork(Network{
 		NetviewName: netview,
 		Cidr:        cidr,
+		Options:     options,
 		Ea:          objMgr.getBasicEA(true)})
 
 	if name != "" {
 		network.Ea["Network Name"] = name
 	}
-	ref, err := objMgr.connector.CreateObject(network)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, network)
 	if err != nil {
 		return nil, err
 	}
@@ -148,12 +180,17 @@ func (objMgr *ObjectManager) CreateNetwork(netview string, cidr string, name str
 
 // CreateNetworkContainer https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) CreateNetworkContainer(netview string, cidr string) (*NetworkContainer, error) {
+	return objMgr.CreateNetworkContainerWithContext(context.Background(), netview, cidr)
+}
+
+// CreateNetworkContainerWithContext is like CreateNetworkContainer but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateNetworkContainerWithContext(ctx context.Context, netview string, cidr string) (*NetworkContainer, error) {
 	container := NewNetworkContainer(NetworkContainer{
 		NetviewName: netview,
 		Cidr:        cidr,
 		Ea:          objMgr.getBasicEA(true)})
 
-	ref, err := objMgr.connector.CreateObject(container)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, container)
 	container.Ref = ref
 
 	return container, err
@@ -161,11 +198,16 @@ func (objMgr *ObjectManager) CreateNetworkContainer(netview string, cidr string)
 
 // GetNetworkView https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetNetworkView(name string) (*NetworkView, error) {
+	return objMgr.GetNetworkViewWithContext(context.Background(), name)
+}
+
+// GetNetworkViewWithContext is like GetNetworkView but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNetworkViewWithContext(ctx context.Context, name string) (*NetworkView, error) {
 	var res []NetworkView
 
 	netview := NewNetworkView(NetworkView{Name: name})
 
-	err := objMgr.connector.GetObject(netview, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, netview, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -176,11 +218,16 @@ func (objMgr *ObjectManager) GetNetworkView(name string) (*NetworkView, error) {
 
 // UpdateNetworkViewEA https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) UpdateNetworkViewEA(ref string, addEA EA, removeEA EA) error {
+	return objMgr.UpdateNetworkViewEAWithContext(context.Background(), ref, addEA, removeEA)
+}
+
+// UpdateNetworkViewEAWithContext is like UpdateNetworkViewEA but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateNetworkViewEAWithContext(ctx context.Context, ref string, addEA EA, removeEA EA) error {
 	var res NetworkView
 
 	nv := NetworkView{}
 	nv.returnFields = []string{"extattrs"}
-	err := objMgr.connector.GetObject(&nv, ref, &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, &nv, ref, &res)
 
 	if err != nil {
 		return err
@@ -197,7 +244,7 @@ func (objMgr *ObjectManager) UpdateNetworkViewEA(ref string, addEA EA, removeEA
 		}
 	}
 
-	_, err = objMgr.connector.UpdateObject(&res, ref)
+	_, err = objMgr.connector.UpdateObjectWithContext(ctx, &res, ref)
 	return err
 }
 
@@ -218,9 +265,10 @@ func BuildNetworkViewFromRef(ref string) *NetworkView {
 }
 
 // BuildNetworkFromRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+// It also accepts the references of IPv6 networks.
 func BuildNetworkFromRef(ref string) *Network {
 	// network/ZG5zLm5ldHdvcmskODkuMC4wLjAvMjQvMjU:89.0.0.0/24/global_view
-	r := regexp.MustCompile(`network/\w+:(\d+\.\d+\.\d+\.\d+/\d+)/(.+)`)
+	r := regexp.MustCompile(`network/\w+:([0-9A-Fa-f.:]+/\d+)/(.+)`)
 	m := r.FindStringSubmatch(ref)
 
 	if m == nil {
@@ -234,8 +282,30 @@ func BuildNetworkFromRef(ref string) *Network {
 	}
 }
 
+// BuildIPv6NetworkFromRef returns the IPv6Network described by a reference
+// such as ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi82NC8w:2001:db8::/64/default
+func BuildIPv6NetworkFromRef(ref string) *IPv6Network {
+	r := regexp.MustCompile(`ipv6network/\w+:([0-9A-Fa-f:.]+/\d+)/(.+)`)
+	m := r.FindStringSubmatch(ref)
+
+	if m == nil {
+		return nil
+	}
+
+	return &IPv6Network{
+		Ref:         ref,
+		NetviewName: m[2],
+		Cidr:        m[1],
+	}
+}
+
 // GetNetwork https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetNetwork(netview string, cidr string, ea EA) (*Network, error) {
+	return objMgr.GetNetworkWithContext(context.Background(), netview, cidr, ea)
+}
+
+// GetNetworkWithContext is like GetNetwork but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNetworkWithContext(ctx context.Context, netview string, cidr string, ea EA) (*Network, error) {
 	var res []Network
 
 	network := NewNetwork(Network{
@@ -249,7 +319,7 @@ func (objMgr *ObjectManager) GetNetwork(netview string, cidr string, ea EA) (*Ne
 		network.eaSearch = EASearch(ea)
 	}
 
-	err := objMgr.connector.GetObject(network, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, network, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -260,20 +330,30 @@ func (objMgr *ObjectManager) GetNetwork(netview string, cidr string, ea EA) (*Ne
 
 // GetNetworkwithref https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetNetworkwithref(ref string) (*Network, error) {
+	return objMgr.GetNetworkwithrefWithContext(context.Background(), ref)
+}
+
+// GetNetworkwithrefWithContext is like GetNetworkwithref but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNetworkwithrefWithContext(ctx context.Context, ref string) (*Network, error) {
 	network := NewNetwork(Network{})
-	err := objMgr.connector.GetObject(network, ref, &network)
+	err := objMgr.connector.GetObjectWithContext(ctx, network, ref, &network)
 	return network, err
 }
 
 // GetNetworkContainer https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetNetworkContainer(netview string, cidr string) (*NetworkContainer, error) {
+	return objMgr.GetNetworkContainerWithContext(context.Background(), netview, cidr)
+}
+
+// GetNetworkContainerWithContext is like GetNetworkContainer but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNetworkContainerWithContext(ctx context.Context, netview string, cidr string) (*NetworkContainer, error) {
 	var res []NetworkContainer
 
 	nwcontainer := NewNetworkContainer(NetworkContainer{
 		NetviewName: netview,
 		Cidr:        cidr})
 
-	err := objMgr.connector.GetObject(nwcontainer, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, nwcontainer, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -283,9 +363,10 @@ func (objMgr *ObjectManager) GetNetworkContainer(netview string, cidr string) (*
 }
 
 // GetIPAddressFromRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+// It also accepts the references of IPv6 fixed addresses.
 func GetIPAddressFromRef(ref string) string {
 	// fixedaddress/ZG5zLmJpbmRfY25h:12.0.10.1/external
-	r := regexp.MustCompile(`fixedaddress/\w+:(\d+\.\d+\.\d+\.\d+)/.+`)
+	r := regexp.MustCompile(`fixedaddress/\w+:([0-9A-Fa-f.:]+)/.+`)
 	m := r.FindStringSubmatch(ref)
 
 	if m != nil {
@@ -296,6 +377,22 @@ func GetIPAddressFromRef(ref string) string {
 
 // AllocateIP https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr string, macAddress string, name string, ea EA) (*FixedAddress, error) {
+	return objMgr.AllocateIPWithContext(context.Background(), netview, cidr, ipAddr, macAddress, name, ea)
+}
+
+// AllocateIPWithContext is like AllocateIP but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AllocateIPWithContext(ctx context.Context, netview string, cidr string, ipAddr string, macAddress string, name string, ea EA) (*FixedAddress, error) {
+	return objMgr.AllocateIPWithOptionsWithContext(ctx, netview, cidr, ipAddr, macAddress, name, ea, nil)
+}
+
+// AllocateIPWithOptions is like AllocateIP but also sets the DHCP options
+// of the fixed address
+func (objMgr *ObjectManager) AllocateIPWithOptions(netview string, cidr string, ipAddr string, macAddress string, name string, ea EA, options []DhcpOption) (*FixedAddress, error) {
+	return objMgr.AllocateIPWithOptionsWithContext(context.Background(), netview, cidr, ipAddr, macAddress, name, ea, options)
+}
+
+// AllocateIPWithOptionsWithContext is like AllocateIPWithOptions but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AllocateIPWithOptionsWithContext(ctx context.Context, netview string, cidr string, ipAddr string, macAddress string, name string, ea EA, options []DhcpOption) (*FixedAddress, error) {
 	if len(macAddress) == 0 {
 		macAddress = "00:00:00:00:00:00"
 	}
@@ -307,6 +404,7 @@ func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr stri
 		Cidr:        cidr,
 		Mac:         macAddress,
 		Name:        name,
+		Options:     options,
 		Ea:          eas})
 
 	if ipAddr == "" {
@@ -315,7 +413,7 @@ func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr stri
 		fixedAddr.IPAddress = ipAddr
 	}
 
-	ref, err := objMgr.connector.CreateObject(fixedAddr)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, fixedAddr)
 	fixedAddr.Ref = ref
 	fixedAddr.IPAddress = GetIPAddressFromRef(ref)
 
@@ -324,6 +422,11 @@ func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr stri
 
 // AllocateNetwork https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) AllocateNetwork(netview string, cidr string, prefixLen uint, name string) (network *Network, err error) {
+	return objMgr.AllocateNetworkWithContext(context.Background(), netview, cidr, prefixLen, name)
+}
+
+// AllocateNetworkWithContext is like AllocateNetwork but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AllocateNetworkWithContext(ctx context.Context, netview string, cidr string, prefixLen uint, name string) (network *Network, err error) {
 	network = nil
 
 	networkReq := NewNetwork(Network{
@@ -334,7 +437,7 @@ func (objMgr *ObjectManager) AllocateNetwork(netview string, cidr string, prefix
 		networkReq.Ea["Network Name"] = name
 	}
 
-	ref, err := objMgr.connector.CreateObject(networkReq)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, networkReq)
 	if err == nil && len(ref) > 0 {
 		network = BuildNetworkFromRef(ref)
 	}
@@ -342,8 +445,60 @@ func (objMgr *ObjectManager) AllocateNetwork(netview string, cidr string, prefix
 	return
 }
 
+// GetNextAvailableIPs returns the next num available addresses of the
+// network, IPv6 network or range with reference ref, skipping those in
+// exclude. The addresses are not reserved, create objects using them to do
+// so.
+func (objMgr *ObjectManager) GetNextAvailableIPs(ref string, num int, exclude []string) ([]string, error) {
+	return objMgr.GetNextAvailableIPsWithContext(context.Background(), ref, num, exclude)
+}
+
+// GetNextAvailableIPsWithContext is like GetNextAvailableIPs but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNextAvailableIPsWithContext(ctx context.Context, ref string, num int, exclude []string) ([]string, error) {
+	args := NewNextAvailableIPArgs(NextAvailableIPArgs{Num: num, Exclude: exclude})
+
+	var res struct {
+		IPs []string `json:"ips"`
+	}
+	err := objMgr.callFunction(ctx, ref, "next_available_ip", args, &res)
+	return res.IPs, err
+}
+
+// GetNextAvailableNetworks returns the next num available networks of
+// prefixLen bits of the network container or network with reference ref,
+// skipping those in exclude. The networks are not reserved.
+func (objMgr *ObjectManager) GetNextAvailableNetworks(ref string, prefixLen uint, num int, exclude []string) ([]string, error) {
+	return objMgr.GetNextAvailableNetworksWithContext(context.Background(), ref, prefixLen, num, exclude)
+}
+
+// GetNextAvailableNetworksWithContext is like GetNextAvailableNetworks but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNextAvailableNetworksWithContext(ctx context.Context, ref string, prefixLen uint, num int, exclude []string) ([]string, error) {
+	args := NewNextAvailableNetworkArgs(NextAvailableNetworkArgs{Cidr: prefixLen, Num: num, Exclude: exclude})
+
+	var res struct {
+		Networks []string `json:"networks"`
+	}
+	err := objMgr.callFunction(ctx, ref, "next_available_network", args, &res)
+	return res.Networks, err
+}
+
+// callFunction calls the WAPI function on the object with reference ref,
+// if the connector supports it
+func (objMgr *ObjectManager) callFunction(ctx context.Context, ref string, function string, args IBObject, res interface{}) error {
+	fc, ok := objMgr.connector.(FunctionCaller)
+	if !ok {
+		return fmt.Errorf("connector %T cannot call WAPI functions", objMgr.connector)
+	}
+	return fc.CallFunctionWithContext(ctx, ref, function, args, res)
+}
+
 // GetFixedAddress https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetFixedAddress(netview string, cidr string, ipAddr string, macAddr string) (*FixedAddress, error) {
+	return objMgr.GetFixedAddressWithContext(context.Background(), netview, cidr, ipAddr, macAddr)
+}
+
+// GetFixedAddressWithContext is like GetFixedAddress but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetFixedAddressWithContext(ctx context.Context, netview string, cidr string, ipAddr string, macAddr string) (*FixedAddress, error) {
 	var res []FixedAddress
 
 	fixedAddr := NewFixedAddress(FixedAddress{
@@ -355,7 +510,7 @@ func (objMgr *ObjectManager) GetFixedAddress(netview string, cidr string, ipAddr
 		fixedAddr.Mac = macAddr
 	}
 
-	err := objMgr.connector.GetObject(fixedAddr, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, fixedAddr, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -366,14 +521,24 @@ func (objMgr *ObjectManager) GetFixedAddress(netview string, cidr string, ipAddr
 
 // GetFixedAddressByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetFixedAddressByRef(ref string) (*FixedAddress, error) {
+	return objMgr.GetFixedAddressByRefWithContext(context.Background(), ref)
+}
+
+// GetFixedAddressByRefWithContext is like GetFixedAddressByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetFixedAddressByRefWithContext(ctx context.Context, ref string) (*FixedAddress, error) {
 	fixedAddr := NewFixedAddress(FixedAddress{})
-	err := objMgr.connector.GetObject(fixedAddr, ref, &fixedAddr)
+	err := objMgr.connector.GetObjectWithContext(ctx, fixedAddr, ref, &fixedAddr)
 	return fixedAddr, err
 }
 
 // DeleteFixedAddress https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) DeleteFixedAddress(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.DeleteFixedAddressWithContext(context.Background(), ref)
+}
+
+// DeleteFixedAddressWithContext is like DeleteFixedAddress but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteFixedAddressWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
 }
 
 // validation  for match_client
@@ -390,7 +555,24 @@ func validateMatchClient(value string) bool {
 
 // UpdateFixedAddress https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) UpdateFixedAddress(fixedAddrRef string, matchClient string, macAddress string, vmID string, vmName string) (*FixedAddress, error) {
-	updateFixedAddr := NewFixedAddress(FixedAddress{Ref: fixedAddrRef})
+	return objMgr.UpdateFixedAddressWithContext(context.Background(), fixedAddrRef, matchClient, macAddress, vmID, vmName)
+}
+
+// UpdateFixedAddressWithContext is like UpdateFixedAddress but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateFixedAddressWithContext(ctx context.Context, fixedAddrRef string, matchClient string, macAddress string, vmID string, vmName string) (*FixedAddress, error) {
+	return objMgr.UpdateFixedAddressWithOptionsWithContext(ctx, fixedAddrRef, matchClient, macAddress, vmID, vmName, nil)
+}
+
+// UpdateFixedAddressWithOptions is like UpdateFixedAddress but also replaces
+// the DHCP options of the fixed address, which are left unchanged if nil and
+// removed if empty
+func (objMgr *ObjectManager) UpdateFixedAddressWithOptions(fixedAddrRef string, matchClient string, macAddress string, vmID string, vmName string, options []DhcpOption) (*FixedAddress, error) {
+	return objMgr.UpdateFixedAddressWithOptionsWithContext(context.Background(), fixedAddrRef, matchClient, macAddress, vmID, vmName, options)
+}
+
+// UpdateFixedAddressWithOptionsWithContext is like UpdateFixedAddressWithOptions but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateFixedAddressWithOptionsWithContext(ctx context.Context, fixedAddrRef string, matchClient string, macAddress string, vmID string, vmName string, options []DhcpOption) (*FixedAddress, error) {
+	updateFixedAddr := NewFixedAddress(FixedAddress{Ref: fixedAddrRef, Options: options})
 
 	if len(macAddress) != 0 {
 		updateFixedAddr.Mac = macAddress
@@ -413,281 +595,1972 @@ func (objMgr *ObjectManager) UpdateFixedAddress(fixedAddrRef string, matchClient
 		}
 	}
 
-	refResp, err := objMgr.connector.UpdateObject(updateFixedAddr, fixedAddrRef)
+	refResp, err := objMgr.connector.UpdateObjectWithContext(ctx, updateOptions(updateFixedAddr, options), fixedAddrRef)
 	updateFixedAddr.Ref = refResp
 	return updateFixedAddr, err
 }
 
 // ReleaseIP https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) ReleaseIP(netview string, cidr string, ipAddr string, macAddr string) (string, error) {
-	fixAddress, _ := objMgr.GetFixedAddress(netview, cidr, ipAddr, macAddr)
+	return objMgr.ReleaseIPWithContext(context.Background(), netview, cidr, ipAddr, macAddr)
+}
+
+// ReleaseIPWithContext is like ReleaseIP but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) ReleaseIPWithContext(ctx context.Context, netview string, cidr string, ipAddr string, macAddr string) (string, error) {
+	fixAddress, err := objMgr.GetFixedAddressWithContext(ctx, netview, cidr, ipAddr, macAddr)
+	if err != nil {
+		loggerOrNop(objMgr.Logger).Error("Failed to get fixed address to release", "network_view", netview, "cidr", cidr, "ip", ipAddr, "error", err)
+	}
 	if fixAddress == nil {
 		return "", nil
 	}
-	return objMgr.connector.DeleteObject(fixAddress.Ref)
+	return objMgr.connector.DeleteObjectWithContext(ctx, fixAddress.Ref)
 }
 
 // DeleteNetwork https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) DeleteNetwork(ref string, netview string) (string, error) {
+	return objMgr.DeleteNetworkWithContext(context.Background(), ref, netview)
+}
+
+// DeleteNetworkWithContext is like DeleteNetwork but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteNetworkWithContext(ctx context.Context, ref string, netview string) (string, error) {
 	network := BuildNetworkFromRef(ref)
 	if network != nil && network.NetviewName == netview {
-		return objMgr.connector.DeleteObject(ref)
+		return objMgr.connector.DeleteObjectWithContext(ctx, ref)
 	}
 
 	return "", nil
 }
 
-// DeleteNetworkView https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) DeleteNetworkView(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+// setRangeServer sets the server association of r, server being the name
+// of the member or of the failover association serving it
+func setRangeServer(r *Range, serverAssociationType string, server string) error {
+	r.ServerAssociationType = serverAssociationType
+	switch serverAssociationType {
+	case "", ServerAssociationNone:
+	case ServerAssociationMember:
+		r.Member = &DhcpMember{Name: server}
+	case ServerAssociationFailover:
+		r.FailoverAssociation = server
+	default:
+		return fmt.Errorf("unsupported server association type %q", serverAssociationType)
+	}
+	return nil
 }
 
-// GetEADefinition https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetEADefinition(name string) (*EADefinition, error) {
-	var res []EADefinition
-
-	eadef := NewEADefinition(EADefinition{Name: name})
-
-	err := objMgr.connector.GetObject(eadef, "", &res)
+// CreateRange creates the DHCP range startAddr-endAddr in the network cidr.
+// serverAssociationType is one of the ServerAssociation constants, server
+// is then the name of the member or of the failover association serving the
+// range. The range gets the DHCP options given, if any.
+func (objMgr *ObjectManager) CreateRange(netview string, cidr string, startAddr string, endAddr string, serverAssociationType string, server string, exclude []ExclusionRange, ea EA, options ...DhcpOption) (*Range, error) {
+	return objMgr.CreateRangeWithContext(context.Background(), netview, cidr, startAddr, endAddr, serverAssociationType, server, exclude, ea, options...)
+}
 
-	if err != nil || res == nil || len(res) == 0 {
+// CreateRangeWithContext is like CreateRange but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateRangeWithContext(ctx context.Context, netview string, cidr string, startAddr string, endAddr string, serverAssociationType string, server string, exclude []ExclusionRange, ea EA, options ...DhcpOption) (*Range, error) {
+	r := NewRange(Range{
+		NetviewName: netview,
+		Cidr:        cidr,
+		StartAddr:   startAddr,
+		EndAddr:     endAddr,
+		Exclude:     exclude,
+		Options:     options,
+		Ea:          objMgr.extendEA(ea)})
+	if err := setRangeServer(r, serverAssociationType, server); err != nil {
 		return nil, err
 	}
 
-	return &res[0], nil
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, r)
+	r.Ref = ref
+	return r, err
 }
 
-// CreateEADefinition https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) CreateEADefinition(eadef EADefinition) (*EADefinition, error) {
-	newEadef := NewEADefinition(eadef)
-
-	ref, err := objMgr.connector.CreateObject(newEadef)
-	newEadef.Ref = ref
-
-	return newEadef, err
+// CreateRangeInNetwork creates a DHCP range spanning percent of the usable
+// addresses of network, at its end, so the first addresses are left for
+// static assignments
+func (objMgr *ObjectManager) CreateRangeInNetwork(network *Network, percent int, serverAssociationType string, server string, ea EA, options ...DhcpOption) (*Range, error) {
+	return objMgr.CreateRangeInNetworkWithContext(context.Background(), network, percent, serverAssociationType, server, ea, options...)
 }
 
-// CreateHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) CreateHostRecord(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, ea EA) (*HostRecord, error) {
-
-	eas := objMgr.extendEA(ea)
+// CreateRangeInNetworkWithContext is like CreateRangeInNetwork but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateRangeInNetworkWithContext(ctx context.Context, network *Network, percent int, serverAssociationType string, server string, ea EA, options ...DhcpOption) (*Range, error) {
+	startAddr, endAddr, err := rangeOfNetwork(network.Cidr, percent)
+	if err != nil {
+		return nil, err
+	}
+	return objMgr.CreateRangeWithContext(ctx, network.NetviewName, network.Cidr, startAddr, endAddr, serverAssociationType, server, nil, ea, options...)
+}
 
-	recordHostIPAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Mac: macAddress})
+// rangeOfNetwork returns the first and last addresses of the last percent
+// of the usable addresses of the IPv4 network cidr
+func rangeOfNetwork(cidr string, percent int) (string, string, error) {
+	if percent < 1 || percent > 100 {
+		return "", "", fmt.Errorf("invalid percentage %d, expected 1 to 100", percent)
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ipNet.IP.To4() == nil {
+		return "", "", fmt.Errorf("invalid IPv4 network %q", cidr)
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones < 2 {
+		return "", "", fmt.Errorf("network %s has no room for a range", cidr)
+	}
 
-	if ipAddr == "" {
-		recordHostIPAddr.Ipv4Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
-	} else {
-		recordHostIPAddr.Ipv4Addr = ipAddr
+	first := binary.BigEndian.Uint32(ipNet.IP.To4()) + 1
+	usable := uint64(1)<<uint(bits-ones) - 2
+	size := usable * uint64(percent) / 100
+	if size == 0 {
+		return "", "", fmt.Errorf("%d%% of network %s is less than an address", percent, cidr)
 	}
-	enableDNS := new(bool)
-	*enableDNS = enabledns
-	recordHostIPAddrSlice := []HostRecordIpv4Addr{*recordHostIPAddr}
-	recordHost := NewHostRecord(HostRecord{
-		Name:        recordName,
-		EnableDNS:   enableDNS,
-		NetworkView: netview,
-		View:        dnsview,
-		Ipv4Addrs:   recordHostIPAddrSlice,
-		Ea:          eas})
+	last := first + uint32(usable) - 1
+	start := last - uint32(size) + 1
 
-	ref, err := objMgr.connector.CreateObject(recordHost)
-	if err != nil {
-		return nil, err
+	ip := func(v uint32) string {
+		b := make(net.IP, 4)
+		binary.BigEndian.PutUint32(b, v)
+		return b.String()
 	}
-	recordHost.Ref = ref
-	err = objMgr.connector.GetObject(recordHost, ref, &recordHost)
-	return recordHost, err
+	return ip(start), ip(last), nil
 }
 
-// GetHostRecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetHostRecordByRef(ref string) (*HostRecord, error) {
-	recordHost := NewHostRecord(HostRecord{})
-	err := objMgr.connector.GetObject(recordHost, ref, &recordHost)
-	return recordHost, err
+// GetRangeByRef returns the DHCP range with reference ref
+func (objMgr *ObjectManager) GetRangeByRef(ref string) (*Range, error) {
+	return objMgr.GetRangeByRefWithContext(context.Background(), ref)
 }
 
-// GetHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetHostRecord(recordName string) (*HostRecord, error) {
-	var res []HostRecord
+// GetRangeByRefWithContext is like GetRangeByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetRangeByRefWithContext(ctx context.Context, ref string) (*Range, error) {
+	r := NewRange(Range{})
+	err := objMgr.connector.GetObjectWithContext(ctx, r, ref, &r)
+	return r, err
+}
 
-	recordHost := NewHostRecord(HostRecord{})
-	if recordName != "" {
-		recordHost.Name = recordName
-	}
+// GetRanges returns the DHCP ranges of netview matching the network and
+// extensible attributes which are not empty
+func (objMgr *ObjectManager) GetRanges(netview string, cidr string, ea EA) ([]Range, error) {
+	return objMgr.GetRangesWithContext(context.Background(), netview, cidr, ea)
+}
 
-	err := objMgr.connector.GetObject(recordHost, "", &res)
+// GetRangesWithContext is like GetRanges but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetRangesWithContext(ctx context.Context, netview string, cidr string, ea EA) ([]Range, error) {
+	var res []Range
 
-	if err != nil || res == nil || len(res) == 0 {
-		return nil, err
+	r := NewRange(Range{
+		NetviewName: netview,
+		Cidr:        cidr})
+	if len(ea) > 0 {
+		r.eaSearch = EASearch(ea)
 	}
-	return &res[0], err
 
+	err := objMgr.connector.GetObjectWithContext(ctx, r, "", &res)
+	return res, err
 }
 
-// GetIPAddressFromHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetIPAddressFromHostRecord(host HostRecord) (string, error) {
-	err := objMgr.connector.GetObject(&host, host.Ref, &host)
-	return host.Ipv4Addrs[0].Ipv4Addr, err
+// UpdateRange changes the DHCP range with reference ref. The addresses,
+// server association and extensible attributes are left unchanged if empty,
+// the exclusions and DHCP options if nil, exclude and options replace those
+// of the range and remove them if empty but not nil.
+func (objMgr *ObjectManager) UpdateRange(ref string, startAddr string, endAddr string, serverAssociationType string, server string, exclude []ExclusionRange, ea EA, options ...DhcpOption) (*Range, error) {
+	return objMgr.UpdateRangeWithContext(context.Background(), ref, startAddr, endAddr, serverAssociationType, server, exclude, ea, options...)
 }
 
-// UpdateHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) UpdateHostRecord(hostRref string, ipAddr string, macAddress string, vmID string, vmName string) (string, error) {
-
-	recordHostIPAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Mac: macAddress, Ipv4Addr: ipAddr})
-	recordHostIPAddrSlice := []HostRecordIpv4Addr{*recordHostIPAddr}
-	updateHostRecord := NewHostRecord(HostRecord{Ipv4Addrs: recordHostIPAddrSlice})
-
-	ea := objMgr.getBasicEA(true)
-	if vmID != "" {
-		ea["VM ID"] = vmID
-		updateHostRecord.Ea = ea
+// UpdateRangeWithContext is like UpdateRange but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateRangeWithContext(ctx context.Context, ref string, startAddr string, endAddr string, serverAssociationType string, server string, exclude []ExclusionRange, ea EA, options ...DhcpOption) (*Range, error) {
+	r := NewRange(Range{
+		StartAddr: startAddr,
+		EndAddr:   endAddr,
+		Exclude:   exclude,
+		Options:   options})
+	if err := setRangeServer(r, serverAssociationType, server); err != nil {
+		return nil, err
+	}
+	if len(ea) > 0 {
+		r.Ea = objMgr.extendEA(ea)
 	}
 
-	if vmName != "" {
-		ea["VM Name"] = vmName
-		updateHostRecord.Ea = ea
+	var update IBObject = r
+	if exclude != nil && len(exclude) == 0 {
+		update = clearField(update, "exclude", `[]`)
 	}
-	ref, err := objMgr.connector.UpdateObject(updateHostRecord, hostRref)
-	return ref, err
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateOptions(update, options), ref)
+	r.Ref = newRef
+	return r, err
 }
 
-// DeleteHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) DeleteHostRecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+// DeleteRange deletes the DHCP range with reference ref
+func (objMgr *ObjectManager) DeleteRange(ref string) (string, error) {
+	return objMgr.DeleteRangeWithContext(context.Background(), ref)
 }
 
-// CreateARecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) CreateARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordA, error) {
+// DeleteRangeWithContext is like DeleteRange but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteRangeWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
 
-	eas := objMgr.extendEA(ea)
+// UpdateNetworkOptions replaces the DHCP options of the network with
+// reference ref, removing them if options is empty
+func (objMgr *ObjectManager) UpdateNetworkOptions(ref string, options []DhcpOption) (*Network, error) {
+	return objMgr.UpdateNetworkOptionsWithContext(context.Background(), ref, options)
+}
 
-	recordA := NewRecordA(RecordA{
-		View: dnsview,
-		Name: recordname,
-		Ea:   eas})
+// UpdateNetworkOptionsWithContext is like UpdateNetworkOptions but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateNetworkOptionsWithContext(ctx context.Context, ref string, options []DhcpOption) (*Network, error) {
+	network := NewNetwork(Network{Options: options})
 
-	if ipAddr == "" {
-		recordA.Ipv4Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
-	} else {
-		recordA.Ipv4Addr = ipAddr
+	var update IBObject = network
+	if len(options) == 0 {
+		update = clearField(network, "options", `[]`)
 	}
-	ref, err := objMgr.connector.CreateObject(recordA)
-	recordA.Ref = ref
-	return recordA, err
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, update, ref)
+	network.Ref = newRef
+	return network, err
 }
 
-// GetARecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetARecordByRef(ref string) (*RecordA, error) {
-	recordA := NewRecordA(RecordA{})
-	err := objMgr.connector.GetObject(recordA, ref, &recordA)
-	return recordA, err
+// CreateDhcpOptionSpace creates the custom DHCP option space name
+func (objMgr *ObjectManager) CreateDhcpOptionSpace(name string, comment string) (*DhcpOptionSpace, error) {
+	return objMgr.CreateDhcpOptionSpaceWithContext(context.Background(), name, comment)
 }
 
-// DeleteARecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) DeleteARecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+// CreateDhcpOptionSpaceWithContext is like CreateDhcpOptionSpace but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateDhcpOptionSpaceWithContext(ctx context.Context, name string, comment string) (*DhcpOptionSpace, error) {
+	space := NewDhcpOptionSpace(DhcpOptionSpace{
+		Name:    name,
+		Comment: comment})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, space)
+	space.Ref = ref
+	return space, err
 }
 
-// CreateCNAMERecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) CreateCNAMERecord(canonical string, recordname string, dnsview string, ea EA) (*RecordCNAME, error) {
+// GetDhcpOptionSpace returns the DHCP option space name, or nil if there is
+// none
+func (objMgr *ObjectManager) GetDhcpOptionSpace(name string) (*DhcpOptionSpace, error) {
+	return objMgr.GetDhcpOptionSpaceWithContext(context.Background(), name)
+}
 
-	eas := objMgr.extendEA(ea)
+// GetDhcpOptionSpaceWithContext is like GetDhcpOptionSpace but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetDhcpOptionSpaceWithContext(ctx context.Context, name string) (*DhcpOptionSpace, error) {
+	var res []DhcpOptionSpace
 
-	recordCNAME := NewRecordCNAME(RecordCNAME{
-		View:      dnsview,
-		Name:      recordname,
-		Canonical: canonical,
-		Ea:        eas})
+	space := NewDhcpOptionSpace(DhcpOptionSpace{Name: name})
+	err := objMgr.connector.GetObjectWithContext(ctx, space, "", &res)
 
-	ref, err := objMgr.connector.CreateObject(recordCNAME)
-	recordCNAME.Ref = ref
-	return recordCNAME, err
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+	return &res[0], nil
 }
 
-// GetCNAMERecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetCNAMERecordByRef(ref string) (*RecordCNAME, error) {
-	recordCNAME := NewRecordCNAME(RecordCNAME{})
-	err := objMgr.connector.GetObject(recordCNAME, ref, &recordCNAME)
-	return recordCNAME, err
+// DeleteDhcpOptionSpace deletes the DHCP option space with reference ref
+func (objMgr *ObjectManager) DeleteDhcpOptionSpace(ref string) (string, error) {
+	return objMgr.DeleteDhcpOptionSpaceWithContext(context.Background(), ref)
 }
 
-// DeleteCNAMERecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) DeleteCNAMERecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+// DeleteDhcpOptionSpaceWithContext is like DeleteDhcpOptionSpace but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteDhcpOptionSpaceWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
 }
 
-// CreateTXTRecord creates TXT Record. Use TTL of 0 to inherit TTL from the Zone
-func (objMgr *ObjectManager) CreateTXTRecord(recordname string, text string, ttl int, dnsview string) (*RecordTXT, error) {
+// CreateDhcpOptionDefinition defines the option name with the given code
+// and type in the option space space, "DHCP" being the standard options
+func (objMgr *ObjectManager) CreateDhcpOptionDefinition(space string, name string, code uint32, optionType string) (*DhcpOptionDefinition, error) {
+	return objMgr.CreateDhcpOptionDefinitionWithContext(context.Background(), space, name, code, optionType)
+}
 
-	recordTXT := NewRecordTXT(RecordTXT{
-		View: dnsview,
-		Name: recordname,
-		Text: text,
-		TTL:  ttl,
-	})
+// CreateDhcpOptionDefinitionWithContext is like CreateDhcpOptionDefinition but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateDhcpOptionDefinitionWithContext(ctx context.Context, space string, name string, code uint32, optionType string) (*DhcpOptionDefinition, error) {
+	def := NewDhcpOptionDefinition(DhcpOptionDefinition{
+		Space: space,
+		Name:  name,
+		Code:  code,
+		Type:  optionType})
 
-	ref, err := objMgr.connector.CreateObject(recordTXT)
-	recordTXT.Ref = ref
-	return recordTXT, err
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, def)
+	def.Ref = ref
+	return def, err
 }
 
-// GetTXTRecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetTXTRecordByRef(ref string) (*RecordTXT, error) {
-	recordTXT := NewRecordTXT(RecordTXT{})
-	err := objMgr.connector.GetObject(recordTXT, ref, &recordTXT)
-	return recordTXT, err
+// GetDhcpOptionDefinitions returns the option definitions of the option
+// space space matching name, if not empty
+func (objMgr *ObjectManager) GetDhcpOptionDefinitions(space string, name string) ([]DhcpOptionDefinition, error) {
+	return objMgr.GetDhcpOptionDefinitionsWithContext(context.Background(), space, name)
 }
 
-// GetTXTRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) GetTXTRecord(name string) (*RecordTXT, error) {
-	if name == "" {
-		return nil, fmt.Errorf("name can not be empty")
-	}
-	var res []RecordTXT
+// GetDhcpOptionDefinitionsWithContext is like GetDhcpOptionDefinitions but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetDhcpOptionDefinitionsWithContext(ctx context.Context, space string, name string) ([]DhcpOptionDefinition, error) {
+	var res []DhcpOptionDefinition
 
-	recordTXT := NewRecordTXT(RecordTXT{Name: name})
+	def := NewDhcpOptionDefinition(DhcpOptionDefinition{
+		Space: space,
+		Name:  name})
 
-	err := objMgr.connector.GetObject(recordTXT, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, def, "", &res)
+	return res, err
+}
 
-	if err != nil || res == nil || len(res) == 0 {
-		return nil, err
-	}
+// UpdateDhcpOptionDefinition changes the name, code and type of the option
+// definition with reference ref, those which are empty are left unchanged
+func (objMgr *ObjectManager) UpdateDhcpOptionDefinition(ref string, name string, code uint32, optionType string) (*DhcpOptionDefinition, error) {
+	return objMgr.UpdateDhcpOptionDefinitionWithContext(context.Background(), ref, name, code, optionType)
+}
 
-	return &res[0], nil
+// UpdateDhcpOptionDefinitionWithContext is like UpdateDhcpOptionDefinition but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateDhcpOptionDefinitionWithContext(ctx context.Context, ref string, name string, code uint32, optionType string) (*DhcpOptionDefinition, error) {
+	def := NewDhcpOptionDefinition(DhcpOptionDefinition{
+		Name: name,
+		Code: code,
+		Type: optionType})
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, def, ref)
+	def.Ref = newRef
+	return def, err
 }
 
-// UpdateTXTRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) UpdateTXTRecord(recordname string, text string) (*RecordTXT, error) {
-	var res []RecordTXT
+// DeleteDhcpOptionDefinition deletes the option definition with reference
+// ref
+func (objMgr *ObjectManager) DeleteDhcpOptionDefinition(ref string) (string, error) {
+	return objMgr.DeleteDhcpOptionDefinitionWithContext(context.Background(), ref)
+}
 
-	recordTXT := NewRecordTXT(RecordTXT{Name: recordname})
+// DeleteDhcpOptionDefinitionWithContext is like DeleteDhcpOptionDefinition but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteDhcpOptionDefinitionWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
 
-	err := objMgr.connector.GetObject(recordTXT, "", &res)
+// GetLeaseByRef returns the DHCP lease with reference ref
+func (objMgr *ObjectManager) GetLeaseByRef(ref string) (*Lease, error) {
+	return objMgr.GetLeaseByRefWithContext(context.Background(), ref)
+}
 
-	if err != nil || len(res) == 0 {
-		return nil, err
-	}
+// GetLeaseByRefWithContext is like GetLeaseByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetLeaseByRefWithContext(ctx context.Context, ref string) (*Lease, error) {
+	lease := NewLease(Lease{})
+	err := objMgr.connector.GetObjectWithContext(ctx, lease, ref, &lease)
+	return lease, err
+}
 
-	res[0].Text = text
+// GetLeases returns the DHCP leases of netview matching the network,
+// address, MAC address, client hostname and binding state which are not
+// empty, bindingState being one of the LeaseState constants
+func (objMgr *ObjectManager) GetLeases(netview string, cidr string, ipAddr string, macAddr string, hostname string, bindingState string) ([]Lease, error) {
+	return objMgr.GetLeasesWithContext(context.Background(), netview, cidr, ipAddr, macAddr, hostname, bindingState)
+}
 
-	res[0].Zone = "" //  set the Zone value to "" as its a non writable field
+// GetLeasesWithContext is like GetLeases but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetLeasesWithContext(ctx context.Context, netview string, cidr string, ipAddr string, macAddr string, hostname string, bindingState string) ([]Lease, error) {
+	var res []Lease
 
-	_, err = objMgr.connector.UpdateObject(&res[0], res[0].Ref)
+	lease := NewLease(Lease{
+		NetworkView:    netview,
+		Network:        cidr,
+		Address:        ipAddr,
+		Hardware:       macAddr,
+		ClientHostname: hostname,
+		BindingState:   bindingState})
 
-	if err != nil || res == nil || len(res) == 0 {
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, lease, DefaultPageSize, &res)
+	return res, err
+}
+
+// GetUnreservedLeases returns the active leases of the IPv4 or IPv6
+// network cidr whose address is neither a fixed address nor an address of a
+// host record, i.e. the addresses in use but not reserved
+func (objMgr *ObjectManager) GetUnreservedLeases(netview string, cidr string) ([]Lease, error) {
+	return objMgr.GetUnreservedLeasesWithContext(context.Background(), netview, cidr)
+}
+
+// GetUnreservedLeasesWithContext is like GetUnreservedLeases but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetUnreservedLeasesWithContext(ctx context.Context, netview string, cidr string) ([]Lease, error) {
+	leases, err := objMgr.GetLeasesWithContext(ctx, netview, cidr, "", "", "", LeaseStateActive)
+	if err != nil || len(leases) == 0 {
+		return nil, err
+	}
+
+	reserved, err := objMgr.getReservedAddresses(ctx, netview, cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Lease
+	for _, lease := range leases {
+		if !reserved[canonicalIP(lease.Address)] {
+			res = append(res, lease)
+		}
+	}
+	return res, nil
+}
+
+// getReservedAddresses returns the addresses of the network cidr reserved
+// by fixed addresses and host records
+func (objMgr *ObjectManager) getReservedAddresses(ctx context.Context, netview string, cidr string) (map[string]bool, error) {
+	reserved := make(map[string]bool)
+
+	if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() == nil {
+		var fixedAddrs []IPv6FixedAddress
+		fixedAddr := NewIPv6FixedAddress(IPv6FixedAddress{NetviewName: netview, Cidr: cidr})
+		if err := GetAllObjectsWithContext(ctx, objMgr.connector, fixedAddr, DefaultPageSize, &fixedAddrs); err != nil {
+			return nil, err
+		}
+		for _, fa := range fixedAddrs {
+			reserved[canonicalIP(fa.IPAddress)] = true
+		}
+
+		var hostAddrs []HostRecordIpv6Addr
+		hostAddr := NewHostRecordIpv6Addr(HostRecordIpv6Addr{NetworkView: netview, Cidr: cidr})
+		if err := GetAllObjectsWithContext(ctx, objMgr.connector, hostAddr, DefaultPageSize, &hostAddrs); err != nil {
+			return nil, err
+		}
+		for _, ha := range hostAddrs {
+			reserved[canonicalIP(ha.Ipv6Addr)] = true
+		}
+		return reserved, nil
+	}
+
+	var fixedAddrs []FixedAddress
+	fixedAddr := NewFixedAddress(FixedAddress{NetviewName: netview, Cidr: cidr})
+	if err := GetAllObjectsWithContext(ctx, objMgr.connector, fixedAddr, DefaultPageSize, &fixedAddrs); err != nil {
+		return nil, err
+	}
+	for _, fa := range fixedAddrs {
+		reserved[canonicalIP(fa.IPAddress)] = true
+	}
+
+	var hostAddrs []HostRecordIpv4Addr
+	hostAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{NetworkView: netview, Cidr: cidr})
+	if err := GetAllObjectsWithContext(ctx, objMgr.connector, hostAddr, DefaultPageSize, &hostAddrs); err != nil {
+		return nil, err
+	}
+	for _, ha := range hostAddrs {
+		reserved[canonicalIP(ha.Ipv4Addr)] = true
+	}
+	return reserved, nil
+}
+
+// canonicalIP returns the usual text form of the address ip, so that IPv6
+// addresses written differently match, or ip if it isn't an address
+func canonicalIP(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
+
+// GetIPv4Addresses returns the addresses of the network cidr of netview
+// with the given status, IPAddressStatusUsed or IPAddressStatusUnused, or
+// all of them if status is empty
+func (objMgr *ObjectManager) GetIPv4Addresses(netview string, cidr string, status string) ([]IPv4Address, error) {
+	return objMgr.GetIPv4AddressesWithContext(context.Background(), netview, cidr, status)
+}
+
+// GetIPv4AddressesWithContext is like GetIPv4Addresses but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv4AddressesWithContext(ctx context.Context, netview string, cidr string, status string) ([]IPv4Address, error) {
+	var res []IPv4Address
+
+	addr := NewIPv4Address(IPv4Address{
+		NetworkView: netview,
+		Network:     cidr,
+		Status:      status})
+
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, addr, DefaultPageSize, &res)
+	return res, err
+}
+
+// GetIPv4Address returns the status of the address ipAddr of netview, nil
+// if it isn't in a network
+func (objMgr *ObjectManager) GetIPv4Address(netview string, ipAddr string) (*IPv4Address, error) {
+	return objMgr.GetIPv4AddressWithContext(context.Background(), netview, ipAddr)
+}
+
+// GetIPv4AddressWithContext is like GetIPv4Address but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv4AddressWithContext(ctx context.Context, netview string, ipAddr string) (*IPv4Address, error) {
+	var res []IPv4Address
+
+	addr := NewIPv4Address(IPv4Address{NetworkView: netview, IPAddress: ipAddr})
+	err := objMgr.connector.GetObjectWithContext(ctx, addr, "", &res)
+
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
+// GetIPv6Addresses returns the used addresses of the IPv6 network cidr of
+// netview with the given status, see GetIPv4Addresses. Unused IPv6
+// addresses are only returned when searched by address.
+func (objMgr *ObjectManager) GetIPv6Addresses(netview string, cidr string, status string) ([]IPv6Address, error) {
+	return objMgr.GetIPv6AddressesWithContext(context.Background(), netview, cidr, status)
+}
+
+// GetIPv6AddressesWithContext is like GetIPv6Addresses but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6AddressesWithContext(ctx context.Context, netview string, cidr string, status string) ([]IPv6Address, error) {
+	var res []IPv6Address
+
+	addr := NewIPv6Address(IPv6Address{
+		NetworkView: netview,
+		Network:     cidr,
+		Status:      status})
+
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, addr, DefaultPageSize, &res)
+	return res, err
+}
+
+// GetIPv6Address returns the status of the IPv6 address ipAddr of netview,
+// nil if it isn't in a network
+func (objMgr *ObjectManager) GetIPv6Address(netview string, ipAddr string) (*IPv6Address, error) {
+	return objMgr.GetIPv6AddressWithContext(context.Background(), netview, ipAddr)
+}
+
+// GetIPv6AddressWithContext is like GetIPv6Address but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6AddressWithContext(ctx context.Context, netview string, ipAddr string) (*IPv6Address, error) {
+	var res []IPv6Address
+
+	addr := NewIPv6Address(IPv6Address{NetworkView: netview, IPAddress: ipAddr})
+	err := objMgr.connector.GetObjectWithContext(ctx, addr, "", &res)
+
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
+// IsIPAddressFree tells whether the IPv4 or IPv6 address ipAddr of netview
+// is unused, i.e. it isn't a fixed address, host address, active lease or
+// DNS record address, nor the address or broadcast address of its network,
+// and can be allocated. An error is returned if ipAddr isn't in a network.
+func (objMgr *ObjectManager) IsIPAddressFree(netview string, ipAddr string) (bool, error) {
+	return objMgr.IsIPAddressFreeWithContext(context.Background(), netview, ipAddr)
+}
+
+// IsIPAddressFreeWithContext is like IsIPAddressFree but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) IsIPAddressFreeWithContext(ctx context.Context, netview string, ipAddr string) (bool, error) {
+	ip := net.ParseIP(ipAddr)
+	if ip == nil {
+		return false, fmt.Errorf("invalid IP address %q", ipAddr)
+	}
+
+	var status string
+	var conflict bool
+	if ip.To4() != nil {
+		addr, err := objMgr.GetIPv4AddressWithContext(ctx, netview, ipAddr)
+		if err != nil {
+			return false, err
+		}
+		if addr == nil {
+			return false, fmt.Errorf("address %s is not in a network of view %s", ipAddr, netview)
+		}
+		status, conflict = addr.Status, addr.IsConflict
+	} else {
+		addr, err := objMgr.GetIPv6AddressWithContext(ctx, netview, ipAddr)
+		if err != nil {
+			return false, err
+		}
+		if addr == nil {
+			return false, fmt.Errorf("address %s is not in a network of view %s", ipAddr, netview)
+		}
+		status, conflict = addr.Status, addr.IsConflict
+	}
+	return status == IPAddressStatusUnused && !conflict, nil
+}
+
+// CreateIPv6Network creates the IPv6 network cidr in netview
+func (objMgr *ObjectManager) CreateIPv6Network(netview string, cidr string, name string) (*IPv6Network, error) {
+	return objMgr.CreateIPv6NetworkWithContext(context.Background(), netview, cidr, name)
+}
+
+// CreateIPv6NetworkWithContext is like CreateIPv6Network but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateIPv6NetworkWithContext(ctx context.Context, netview string, cidr string, name string) (*IPv6Network, error) {
+	network := NewIPv6Network(IPv6Network{
+		NetviewName: netview,
+		Cidr:        cidr,
+		Ea:          objMgr.getBasicEA(true)})
+
+	if name != "" {
+		network.Ea["Network Name"] = name
+	}
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	network.Ref = ref
+
+	return network, err
+}
+
+// CreateIPv6NetworkContainer creates the IPv6 network container cidr in
+// netview
+func (objMgr *ObjectManager) CreateIPv6NetworkContainer(netview string, cidr string) (*IPv6NetworkContainer, error) {
+	return objMgr.CreateIPv6NetworkContainerWithContext(context.Background(), netview, cidr)
+}
+
+// CreateIPv6NetworkContainerWithContext is like CreateIPv6NetworkContainer but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateIPv6NetworkContainerWithContext(ctx context.Context, netview string, cidr string) (*IPv6NetworkContainer, error) {
+	container := NewIPv6NetworkContainer(IPv6NetworkContainer{
+		NetviewName: netview,
+		Cidr:        cidr,
+		Ea:          objMgr.getBasicEA(true)})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, container)
+	container.Ref = ref
+
+	return container, err
+}
+
+// GetIPv6Network returns the IPv6 network cidr of netview with the given
+// extensible attributes, or nil if there is none
+func (objMgr *ObjectManager) GetIPv6Network(netview string, cidr string, ea EA) (*IPv6Network, error) {
+	return objMgr.GetIPv6NetworkWithContext(context.Background(), netview, cidr, ea)
+}
+
+// GetIPv6NetworkWithContext is like GetIPv6Network but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6NetworkWithContext(ctx context.Context, netview string, cidr string, ea EA) (*IPv6Network, error) {
+	var res []IPv6Network
+
+	network := NewIPv6Network(IPv6Network{
+		NetviewName: netview,
+		Cidr:        cidr})
+
+	if len(ea) > 0 {
+		network.eaSearch = EASearch(ea)
+	}
+
+	err := objMgr.connector.GetObjectWithContext(ctx, network, "", &res)
+
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// GetIPv6NetworkContainer returns the IPv6 network container cidr of
+// netview, or nil if there is none
+func (objMgr *ObjectManager) GetIPv6NetworkContainer(netview string, cidr string) (*IPv6NetworkContainer, error) {
+	return objMgr.GetIPv6NetworkContainerWithContext(context.Background(), netview, cidr)
+}
+
+// GetIPv6NetworkContainerWithContext is like GetIPv6NetworkContainer but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6NetworkContainerWithContext(ctx context.Context, netview string, cidr string) (*IPv6NetworkContainer, error) {
+	var res []IPv6NetworkContainer
+
+	container := NewIPv6NetworkContainer(IPv6NetworkContainer{
+		NetviewName: netview,
+		Cidr:        cidr})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, container, "", &res)
+
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// AllocateIPv6Network creates the next available IPv6 network of prefixLen
+// bits in the IPv6 network container cidr
+func (objMgr *ObjectManager) AllocateIPv6Network(netview string, cidr string, prefixLen uint, name string) (*IPv6Network, error) {
+	return objMgr.AllocateIPv6NetworkWithContext(context.Background(), netview, cidr, prefixLen, name)
+}
+
+// AllocateIPv6NetworkWithContext is like AllocateIPv6Network but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AllocateIPv6NetworkWithContext(ctx context.Context, netview string, cidr string, prefixLen uint, name string) (*IPv6Network, error) {
+	networkReq := NewIPv6Network(IPv6Network{
+		NetviewName: netview,
+		Cidr:        fmt.Sprintf("func:nextavailablenetwork:%s,%s,%d", cidr, netview, prefixLen),
+		Ea:          objMgr.getBasicEA(true)})
+	if name != "" {
+		networkReq.Ea["Network Name"] = name
+	}
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, networkReq)
+	if err != nil || len(ref) == 0 {
+		return nil, err
+	}
+
+	return BuildIPv6NetworkFromRef(ref), nil
+}
+
+// AllocateIPv6 creates an IPv6 fixed address for the client duid in the
+// IPv6 network cidr, at ipAddr or at the next available address if ipAddr
+// is empty
+func (objMgr *ObjectManager) AllocateIPv6(netview string, cidr string, ipAddr string, duid string, name string, ea EA) (*IPv6FixedAddress, error) {
+	return objMgr.AllocateIPv6WithContext(context.Background(), netview, cidr, ipAddr, duid, name, ea)
+}
+
+// AllocateIPv6WithContext is like AllocateIPv6 but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AllocateIPv6WithContext(ctx context.Context, netview string, cidr string, ipAddr string, duid string, name string, ea EA) (*IPv6FixedAddress, error) {
+	if len(duid) == 0 {
+		duid = "00:00:00:00:00:00"
+	}
+
+	fixedAddr := NewIPv6FixedAddress(IPv6FixedAddress{
+		NetviewName: netview,
+		Cidr:        cidr,
+		Duid:        duid,
+		Name:        name,
+		Ea:          objMgr.extendEA(ea)})
+
+	if ipAddr == "" {
+		fixedAddr.IPAddress = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		fixedAddr.IPAddress = ipAddr
+	}
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, fixedAddr)
+	fixedAddr.Ref = ref
+	fixedAddr.IPAddress = GetIPAddressFromRef(ref)
+
+	return fixedAddr, err
+}
+
+// GetIPv6FixedAddress returns the IPv6 fixed address ipAddr of the network
+// cidr, or nil if there is none. duid is only matched if not empty.
+func (objMgr *ObjectManager) GetIPv6FixedAddress(netview string, cidr string, ipAddr string, duid string) (*IPv6FixedAddress, error) {
+	return objMgr.GetIPv6FixedAddressWithContext(context.Background(), netview, cidr, ipAddr, duid)
+}
+
+// GetIPv6FixedAddressWithContext is like GetIPv6FixedAddress but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6FixedAddressWithContext(ctx context.Context, netview string, cidr string, ipAddr string, duid string) (*IPv6FixedAddress, error) {
+	var res []IPv6FixedAddress
+
+	fixedAddr := NewIPv6FixedAddress(IPv6FixedAddress{
+		NetviewName: netview,
+		Cidr:        cidr,
+		IPAddress:   ipAddr,
+		Duid:        duid})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, fixedAddr, "", &res)
+
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// GetIPv6FixedAddressByRef returns the IPv6 fixed address with reference ref
+func (objMgr *ObjectManager) GetIPv6FixedAddressByRef(ref string) (*IPv6FixedAddress, error) {
+	return objMgr.GetIPv6FixedAddressByRefWithContext(context.Background(), ref)
+}
+
+// GetIPv6FixedAddressByRefWithContext is like GetIPv6FixedAddressByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6FixedAddressByRefWithContext(ctx context.Context, ref string) (*IPv6FixedAddress, error) {
+	fixedAddr := NewIPv6FixedAddress(IPv6FixedAddress{})
+	err := objMgr.connector.GetObjectWithContext(ctx, fixedAddr, ref, &fixedAddr)
+	return fixedAddr, err
+}
+
+// ReleaseIPv6 deletes the IPv6 fixed address ipAddr of the network cidr,
+// it returns an empty reference if there is none
+func (objMgr *ObjectManager) ReleaseIPv6(netview string, cidr string, ipAddr string, duid string) (string, error) {
+	return objMgr.ReleaseIPv6WithContext(context.Background(), netview, cidr, ipAddr, duid)
+}
+
+// ReleaseIPv6WithContext is like ReleaseIPv6 but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) ReleaseIPv6WithContext(ctx context.Context, netview string, cidr string, ipAddr string, duid string) (string, error) {
+	fixedAddr, err := objMgr.GetIPv6FixedAddressWithContext(ctx, netview, cidr, ipAddr, duid)
+	if err != nil {
+		loggerOrNop(objMgr.Logger).Error("Failed to get IPv6 fixed address to release", "network_view", netview, "cidr", cidr, "ip", ipAddr, "error", err)
+	}
+	if fixedAddr == nil {
+		return "", nil
+	}
+	return objMgr.connector.DeleteObjectWithContext(ctx, fixedAddr.Ref)
+}
+
+// DeleteNetworkView https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) DeleteNetworkView(ref string) (string, error) {
+	return objMgr.DeleteNetworkViewWithContext(context.Background(), ref)
+}
+
+// DeleteNetworkViewWithContext is like DeleteNetworkView but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteNetworkViewWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// GetEADefinition https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetEADefinition(name string) (*EADefinition, error) {
+	return objMgr.GetEADefinitionWithContext(context.Background(), name)
+}
+
+// GetEADefinitionWithContext is like GetEADefinition but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetEADefinitionWithContext(ctx context.Context, name string) (*EADefinition, error) {
+	var res []EADefinition
+
+	eadef := NewEADefinition(EADefinition{Name: name})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, eadef, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// CreateEADefinition https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) CreateEADefinition(eadef EADefinition) (*EADefinition, error) {
+	return objMgr.CreateEADefinitionWithContext(context.Background(), eadef)
+}
+
+// CreateEADefinitionWithContext is like CreateEADefinition but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateEADefinitionWithContext(ctx context.Context, eadef EADefinition) (*EADefinition, error) {
+	newEadef := NewEADefinition(eadef)
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, newEadef)
+	newEadef.Ref = ref
+
+	return newEadef, err
+}
+
+// CreateHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) CreateHostRecord(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, ea EA) (*HostRecord, error) {
+	return objMgr.CreateHostRecordWithContext(context.Background(), enabledns, recordName, netview, dnsview, cidr, ipAddr, macAddress, ea)
+}
+
+// CreateHostRecordWithContext is like CreateHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateHostRecordWithContext(ctx context.Context, enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, ea EA) (*HostRecord, error) {
+
+	eas := objMgr.extendEA(ea)
+
+	recordHostIPAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Mac: macAddress})
+
+	if ipAddr == "" {
+		recordHostIPAddr.Ipv4Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		recordHostIPAddr.Ipv4Addr = ipAddr
+	}
+	enableDNS := new(bool)
+	*enableDNS = enabledns
+	recordHostIPAddrSlice := []HostRecordIpv4Addr{*recordHostIPAddr}
+	recordHost := NewHostRecord(HostRecord{
+		Name:        recordName,
+		EnableDNS:   enableDNS,
+		NetworkView: netview,
+		View:        dnsview,
+		Ipv4Addrs:   recordHostIPAddrSlice,
+		Ea:          eas})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordHost)
+	if err != nil {
+		return nil, err
+	}
+	recordHost.Ref = ref
+	err = objMgr.connector.GetObjectWithContext(ctx, recordHost, ref, &recordHost)
+	return recordHost, err
+}
+
+// GetHostRecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetHostRecordByRef(ref string) (*HostRecord, error) {
+	return objMgr.GetHostRecordByRefWithContext(context.Background(), ref)
+}
+
+// GetHostRecordByRefWithContext is like GetHostRecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetHostRecordByRefWithContext(ctx context.Context, ref string) (*HostRecord, error) {
+	recordHost := NewHostRecord(HostRecord{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordHost, ref, &recordHost)
+	return recordHost, err
+}
+
+// GetHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetHostRecord(recordName string) (*HostRecord, error) {
+	return objMgr.GetHostRecordWithContext(context.Background(), recordName)
+}
+
+// GetHostRecordWithContext is like GetHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetHostRecordWithContext(ctx context.Context, recordName string) (*HostRecord, error) {
+	var res []HostRecord
+
+	recordHost := NewHostRecord(HostRecord{})
+	if recordName != "" {
+		recordHost.Name = recordName
+	}
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordHost, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+	return &res[0], err
+
+}
+
+// GetIPAddressFromHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetIPAddressFromHostRecord(host HostRecord) (string, error) {
+	return objMgr.GetIPAddressFromHostRecordWithContext(context.Background(), host)
+}
+
+// GetIPAddressFromHostRecordWithContext is like GetIPAddressFromHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPAddressFromHostRecordWithContext(ctx context.Context, host HostRecord) (string, error) {
+	err := objMgr.connector.GetObjectWithContext(ctx, &host, host.Ref, &host)
+	if err != nil {
+		return "", err
+	}
+	if len(host.Ipv4Addrs) == 0 {
+		return "", fmt.Errorf("host record %s has no IPv4 address", host.Ref)
+	}
+	return host.Ipv4Addrs[0].Ipv4Addr, nil
+}
+
+// CreateIPv6HostRecord creates a host record with an IPv6 address for the
+// client duid, at ipAddr or at the next available address of the IPv6
+// network cidr if ipAddr is empty
+func (objMgr *ObjectManager) CreateIPv6HostRecord(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, duid string, ea EA) (*HostRecord, error) {
+	return objMgr.CreateIPv6HostRecordWithContext(context.Background(), enabledns, recordName, netview, dnsview, cidr, ipAddr, duid, ea)
+}
+
+// CreateIPv6HostRecordWithContext is like CreateIPv6HostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateIPv6HostRecordWithContext(ctx context.Context, enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, duid string, ea EA) (*HostRecord, error) {
+	recordHostIPAddr := NewHostRecordIpv6Addr(HostRecordIpv6Addr{Duid: duid})
+
+	if ipAddr == "" {
+		recordHostIPAddr.Ipv6Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		recordHostIPAddr.Ipv6Addr = ipAddr
+	}
+	enableDNS := new(bool)
+	*enableDNS = enabledns
+	recordHost := NewHostRecord(HostRecord{
+		Name:        recordName,
+		EnableDNS:   enableDNS,
+		NetworkView: netview,
+		View:        dnsview,
+		Ipv6Addrs:   []HostRecordIpv6Addr{*recordHostIPAddr},
+		Ea:          objMgr.extendEA(ea)})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordHost)
+	if err != nil {
+		return nil, err
+	}
+	recordHost.Ref = ref
+	err = objMgr.connector.GetObjectWithContext(ctx, recordHost, ref, &recordHost)
+	return recordHost, err
+}
+
+// GetIPv6AddressFromHostRecord returns the first IPv6 address of a host
+// record
+func (objMgr *ObjectManager) GetIPv6AddressFromHostRecord(host HostRecord) (string, error) {
+	return objMgr.GetIPv6AddressFromHostRecordWithContext(context.Background(), host)
+}
+
+// GetIPv6AddressFromHostRecordWithContext is like GetIPv6AddressFromHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPv6AddressFromHostRecordWithContext(ctx context.Context, host HostRecord) (string, error) {
+	err := objMgr.connector.GetObjectWithContext(ctx, &host, host.Ref, &host)
+	if err != nil {
+		return "", err
+	}
+	if len(host.Ipv6Addrs) == 0 {
+		return "", fmt.Errorf("host record %s has no IPv6 address", host.Ref)
+	}
+	return host.Ipv6Addrs[0].Ipv6Addr, nil
+}
+
+// GetIPAddressesFromHostRecord returns the IPv4 and IPv6 addresses of host
+func (objMgr *ObjectManager) GetIPAddressesFromHostRecord(host HostRecord) ([]string, error) {
+	return objMgr.GetIPAddressesFromHostRecordWithContext(context.Background(), host)
+}
+
+// GetIPAddressesFromHostRecordWithContext is like GetIPAddressesFromHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetIPAddressesFromHostRecordWithContext(ctx context.Context, host HostRecord) ([]string, error) {
+	err := objMgr.connector.GetObjectWithContext(ctx, &host, host.Ref, &host)
+	if err != nil {
+		return nil, err
+	}
+	res := []string{}
+	for _, addr := range host.Ipv4Addrs {
+		res = append(res, addr.Ipv4Addr)
+	}
+	for _, addr := range host.Ipv6Addrs {
+		res = append(res, addr.Ipv6Addr)
+	}
+	return res, nil
+}
+
+// updateHostRecordLists applies the additions and removals of update to
+// the host record with reference ref and returns the updated record
+func (objMgr *ObjectManager) updateHostRecordLists(ctx context.Context, ref string, update *HostRecord) (*HostRecord, error) {
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, update, ref)
+	if err != nil {
+		return nil, err
+	}
+	return objMgr.GetHostRecordByRefWithContext(ctx, newRef)
+}
+
+// AddHostRecordIPv4Addrs adds addrs to the IPv4 addresses of the host record
+// with reference ref. An address may be allocated with
+// Ipv4Addr: "func:nextavailableip:<cidr>,<netview>".
+func (objMgr *ObjectManager) AddHostRecordIPv4Addrs(ref string, addrs []HostRecordIpv4Addr) (*HostRecord, error) {
+	return objMgr.AddHostRecordIPv4AddrsWithContext(context.Background(), ref, addrs)
+}
+
+// AddHostRecordIPv4AddrsWithContext is like AddHostRecordIPv4Addrs but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AddHostRecordIPv4AddrsWithContext(ctx context.Context, ref string, addrs []HostRecordIpv4Addr) (*HostRecord, error) {
+	return objMgr.updateHostRecordLists(ctx, ref, NewHostRecord(HostRecord{Ipv4AddrsAdd: addrs}))
+}
+
+// RemoveHostRecordIPv4Addrs removes the IPv4 addresses ipAddrs from the host
+// record with reference ref
+func (objMgr *ObjectManager) RemoveHostRecordIPv4Addrs(ref string, ipAddrs []string) (*HostRecord, error) {
+	return objMgr.RemoveHostRecordIPv4AddrsWithContext(context.Background(), ref, ipAddrs)
+}
+
+// RemoveHostRecordIPv4AddrsWithContext is like RemoveHostRecordIPv4Addrs but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) RemoveHostRecordIPv4AddrsWithContext(ctx context.Context, ref string, ipAddrs []string) (*HostRecord, error) {
+	var addrs []HostRecordIpv4Addr
+	for _, ip := range ipAddrs {
+		addrs = append(addrs, HostRecordIpv4Addr{Ipv4Addr: ip})
+	}
+	return objMgr.updateHostRecordLists(ctx, ref, NewHostRecord(HostRecord{Ipv4AddrsRemove: addrs}))
+}
+
+// AddHostRecordIPv6Addrs adds addrs to the IPv6 addresses of the host record
+// with reference ref
+func (objMgr *ObjectManager) AddHostRecordIPv6Addrs(ref string, addrs []HostRecordIpv6Addr) (*HostRecord, error) {
+	return objMgr.AddHostRecordIPv6AddrsWithContext(context.Background(), ref, addrs)
+}
+
+// AddHostRecordIPv6AddrsWithContext is like AddHostRecordIPv6Addrs but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AddHostRecordIPv6AddrsWithContext(ctx context.Context, ref string, addrs []HostRecordIpv6Addr) (*HostRecord, error) {
+	return objMgr.updateHostRecordLists(ctx, ref, NewHostRecord(HostRecord{Ipv6AddrsAdd: addrs}))
+}
+
+// RemoveHostRecordIPv6Addrs removes the IPv6 addresses ipAddrs from the host
+// record with reference ref
+func (objMgr *ObjectManager) RemoveHostRecordIPv6Addrs(ref string, ipAddrs []string) (*HostRecord, error) {
+	return objMgr.RemoveHostRecordIPv6AddrsWithContext(context.Background(), ref, ipAddrs)
+}
+
+// RemoveHostRecordIPv6AddrsWithContext is like RemoveHostRecordIPv6Addrs but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) RemoveHostRecordIPv6AddrsWithContext(ctx context.Context, ref string, ipAddrs []string) (*HostRecord, error) {
+	var addrs []HostRecordIpv6Addr
+	for _, ip := range ipAddrs {
+		addrs = append(addrs, HostRecordIpv6Addr{Ipv6Addr: ip})
+	}
+	return objMgr.updateHostRecordLists(ctx, ref, NewHostRecord(HostRecord{Ipv6AddrsRemove: addrs}))
+}
+
+// AddHostRecordAliases adds aliases to the host record with reference ref
+func (objMgr *ObjectManager) AddHostRecordAliases(ref string, aliases []string) (*HostRecord, error) {
+	return objMgr.AddHostRecordAliasesWithContext(context.Background(), ref, aliases)
+}
+
+// AddHostRecordAliasesWithContext is like AddHostRecordAliases but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) AddHostRecordAliasesWithContext(ctx context.Context, ref string, aliases []string) (*HostRecord, error) {
+	return objMgr.updateHostRecordLists(ctx, ref, NewHostRecord(HostRecord{AliasesAdd: aliases}))
+}
+
+// RemoveHostRecordAliases removes aliases from the host record with
+// reference ref
+func (objMgr *ObjectManager) RemoveHostRecordAliases(ref string, aliases []string) (*HostRecord, error) {
+	return objMgr.RemoveHostRecordAliasesWithContext(context.Background(), ref, aliases)
+}
+
+// RemoveHostRecordAliasesWithContext is like RemoveHostRecordAliases but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) RemoveHostRecordAliasesWithContext(ctx context.Context, ref string, aliases []string) (*HostRecord, error) {
+	return objMgr.updateHostRecordLists(ctx, ref, NewHostRecord(HostRecord{AliasesRemove: aliases}))
+}
+
+// UpdateHostRecordIPv4Addr sets the DHCP configuration of the host record
+// address with reference addrRef, as found in the Ipv4Addrs of the record.
+// The MAC address is left unchanged if empty, configureForDHCP and the DHCP
+// options if nil, options replace those of the address and remove them if
+// empty but not nil.
+func (objMgr *ObjectManager) UpdateHostRecordIPv4Addr(addrRef string, macAddress string, configureForDHCP *bool, options []DhcpOption) (*HostRecordIpv4Addr, error) {
+	return objMgr.UpdateHostRecordIPv4AddrWithContext(context.Background(), addrRef, macAddress, configureForDHCP, options)
+}
+
+// UpdateHostRecordIPv4AddrWithContext is like UpdateHostRecordIPv4Addr but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateHostRecordIPv4AddrWithContext(ctx context.Context, addrRef string, macAddress string, configureForDHCP *bool, options []DhcpOption) (*HostRecordIpv4Addr, error) {
+	hostAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{
+		Mac:              macAddress,
+		ConfigureForDHCP: configureForDHCP,
+		Options:          options})
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateOptions(hostAddr, options), addrRef)
+	if err != nil {
+		return nil, err
+	}
+	res := NewHostRecordIpv4Addr(HostRecordIpv4Addr{})
+	err = objMgr.connector.GetObjectWithContext(ctx, res, newRef, &res)
+	return res, err
+}
+
+// UpdateHostRecordIPv6Addr is like UpdateHostRecordIPv4Addr for the IPv6
+// addresses of host records, identified by their DUID instead of a MAC
+// address
+func (objMgr *ObjectManager) UpdateHostRecordIPv6Addr(addrRef string, duid string, configureForDHCP *bool, options []DhcpOption) (*HostRecordIpv6Addr, error) {
+	return objMgr.UpdateHostRecordIPv6AddrWithContext(context.Background(), addrRef, duid, configureForDHCP, options)
+}
+
+// UpdateHostRecordIPv6AddrWithContext is like UpdateHostRecordIPv6Addr but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateHostRecordIPv6AddrWithContext(ctx context.Context, addrRef string, duid string, configureForDHCP *bool, options []DhcpOption) (*HostRecordIpv6Addr, error) {
+	hostAddr := NewHostRecordIpv6Addr(HostRecordIpv6Addr{
+		Duid:             duid,
+		ConfigureForDHCP: configureForDHCP,
+		Options:          options})
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateOptions(hostAddr, options), addrRef)
+	if err != nil {
+		return nil, err
+	}
+	res := NewHostRecordIpv6Addr(HostRecordIpv6Addr{})
+	err = objMgr.connector.GetObjectWithContext(ctx, res, newRef, &res)
+	return res, err
+}
+
+// UpdateHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) UpdateHostRecord(hostRref string, ipAddr string, macAddress string, vmID string, vmName string) (string, error) {
+	return objMgr.UpdateHostRecordWithContext(context.Background(), hostRref, ipAddr, macAddress, vmID, vmName)
+}
+
+// UpdateHostRecordWithContext is like UpdateHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateHostRecordWithContext(ctx context.Context, hostRref string, ipAddr string, macAddress string, vmID string, vmName string) (string, error) {
+
+	recordHostIPAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Mac: macAddress, Ipv4Addr: ipAddr})
+	recordHostIPAddrSlice := []HostRecordIpv4Addr{*recordHostIPAddr}
+	updateHostRecord := NewHostRecord(HostRecord{Ipv4Addrs: recordHostIPAddrSlice})
+
+	ea := objMgr.getBasicEA(true)
+	if vmID != "" {
+		ea["VM ID"] = vmID
+		updateHostRecord.Ea = ea
+	}
+
+	if vmName != "" {
+		ea["VM Name"] = vmName
+		updateHostRecord.Ea = ea
+	}
+	ref, err := objMgr.connector.UpdateObjectWithContext(ctx, updateHostRecord, hostRref)
+	return ref, err
+}
+
+// DeleteHostRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) DeleteHostRecord(ref string) (string, error) {
+	return objMgr.DeleteHostRecordWithContext(context.Background(), ref)
+}
+
+// DeleteHostRecordWithContext is like DeleteHostRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteHostRecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateARecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) CreateARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordA, error) {
+	return objMgr.CreateARecordWithContext(context.Background(), netview, dnsview, recordname, cidr, ipAddr, ea)
+}
+
+// CreateARecordWithContext is like CreateARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateARecordWithContext(ctx context.Context, netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordA, error) {
+
+	eas := objMgr.extendEA(ea)
+
+	recordA := NewRecordA(RecordA{
+		View: dnsview,
+		Name: recordname,
+		Ea:   eas})
+
+	if ipAddr == "" {
+		recordA.Ipv4Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		recordA.Ipv4Addr = ipAddr
+	}
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordA)
+	recordA.Ref = ref
+	return recordA, err
+}
+
+// GetARecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetARecordByRef(ref string) (*RecordA, error) {
+	return objMgr.GetARecordByRefWithContext(context.Background(), ref)
+}
+
+// GetARecordByRefWithContext is like GetARecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetARecordByRefWithContext(ctx context.Context, ref string) (*RecordA, error) {
+	recordA := NewRecordA(RecordA{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordA, ref, &recordA)
+	return recordA, err
+}
+
+// GetARecords returns the A records matching the view, name, address, zone
+// and extensible attributes which are not empty, in every view if dnsview
+// is empty
+func (objMgr *ObjectManager) GetARecords(dnsview string, recordname string, ipAddr string, zone string, ea EA) ([]RecordA, error) {
+	return objMgr.GetARecordsWithContext(context.Background(), dnsview, recordname, ipAddr, zone, ea)
+}
+
+// GetARecordsWithContext is like GetARecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetARecordsWithContext(ctx context.Context, dnsview string, recordname string, ipAddr string, zone string, ea EA) ([]RecordA, error) {
+	var res []RecordA
+
+	recordA := NewRecordA(RecordA{
+		View:     dnsview,
+		Name:     recordname,
+		Ipv4Addr: ipAddr,
+		Zone:     zone})
+	if len(ea) > 0 {
+		recordA.eaSearch = EASearch(ea)
+	}
+
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, recordA, DefaultPageSize, &res)
+	return res, err
+}
+
+// UpdateARecord changes the A record with reference ref. The name,
+// address and extensible attributes are left unchanged if empty, the TTL
+// and comment if nil, a TTL of 0 inherits the one of the Zone.
+func (objMgr *ObjectManager) UpdateARecord(ref string, recordname string, ipAddr string, ttl *uint32, comment *string, ea EA) (*RecordA, error) {
+	return objMgr.UpdateARecordWithContext(context.Background(), ref, recordname, ipAddr, ttl, comment, ea)
+}
+
+// UpdateARecordWithContext is like UpdateARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateARecordWithContext(ctx context.Context, ref string, recordname string, ipAddr string, ttl *uint32, comment *string, ea EA) (*RecordA, error) {
+	// the zone is not writable, so it is never set
+	recordA := NewRecordA(RecordA{
+		Name:     recordname,
+		Ipv4Addr: ipAddr})
+	recordA.TTL, recordA.UseTTL = updateTTL(ttl)
+	if comment != nil {
+		recordA.Comment = *comment
+	}
+
+	if len(ea) > 0 {
+		recordA.Ea = objMgr.extendEA(ea)
+	}
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateComment(recordA, comment), ref)
+	recordA.Ref = newRef
+	return recordA, err
+}
+
+// DeleteARecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) DeleteARecord(ref string) (string, error) {
+	return objMgr.DeleteARecordWithContext(context.Background(), ref)
+}
+
+// DeleteARecordWithContext is like DeleteARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteARecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateAAAARecord creates an AAAA record for ipAddr, or for the next
+// available address of the IPv6 network cidr if ipAddr is empty
+func (objMgr *ObjectManager) CreateAAAARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordAAAA, error) {
+	return objMgr.CreateAAAARecordWithContext(context.Background(), netview, dnsview, recordname, cidr, ipAddr, ea)
+}
+
+// CreateAAAARecordWithContext is like CreateAAAARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateAAAARecordWithContext(ctx context.Context, netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordAAAA, error) {
+	recordAAAA := NewRecordAAAA(RecordAAAA{
+		View: dnsview,
+		Name: recordname,
+		Ea:   objMgr.extendEA(ea)})
+
+	if ipAddr == "" {
+		recordAAAA.Ipv6Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		recordAAAA.Ipv6Addr = ipAddr
+	}
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordAAAA)
+	recordAAAA.Ref = ref
+	return recordAAAA, err
+}
+
+// GetAAAARecordByRef returns the AAAA record with reference ref
+func (objMgr *ObjectManager) GetAAAARecordByRef(ref string) (*RecordAAAA, error) {
+	return objMgr.GetAAAARecordByRefWithContext(context.Background(), ref)
+}
+
+// GetAAAARecordByRefWithContext is like GetAAAARecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetAAAARecordByRefWithContext(ctx context.Context, ref string) (*RecordAAAA, error) {
+	recordAAAA := NewRecordAAAA(RecordAAAA{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordAAAA, ref, &recordAAAA)
+	return recordAAAA, err
+}
+
+// GetAAAARecords returns the AAAA records matching the view, name,
+// address, zone and extensible attributes which are not empty, in every
+// view if dnsview is empty
+func (objMgr *ObjectManager) GetAAAARecords(dnsview string, recordname string, ipAddr string, zone string, ea EA) ([]RecordAAAA, error) {
+	return objMgr.GetAAAARecordsWithContext(context.Background(), dnsview, recordname, ipAddr, zone, ea)
+}
+
+// GetAAAARecordsWithContext is like GetAAAARecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetAAAARecordsWithContext(ctx context.Context, dnsview string, recordname string, ipAddr string, zone string, ea EA) ([]RecordAAAA, error) {
+	var res []RecordAAAA
+
+	recordAAAA := NewRecordAAAA(RecordAAAA{
+		View:     dnsview,
+		Name:     recordname,
+		Ipv6Addr: ipAddr,
+		Zone:     zone})
+	if len(ea) > 0 {
+		recordAAAA.eaSearch = EASearch(ea)
+	}
+
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, recordAAAA, DefaultPageSize, &res)
+	return res, err
+}
+
+// UpdateAAAARecord changes the AAAA record with reference ref. The name,
+// address and extensible attributes are left unchanged if empty, the TTL
+// and comment if nil, a TTL of 0 inherits the one of the Zone.
+func (objMgr *ObjectManager) UpdateAAAARecord(ref string, recordname string, ipAddr string, ttl *uint32, comment *string, ea EA) (*RecordAAAA, error) {
+	return objMgr.UpdateAAAARecordWithContext(context.Background(), ref, recordname, ipAddr, ttl, comment, ea)
+}
+
+// UpdateAAAARecordWithContext is like UpdateAAAARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateAAAARecordWithContext(ctx context.Context, ref string, recordname string, ipAddr string, ttl *uint32, comment *string, ea EA) (*RecordAAAA, error) {
+	recordAAAA := NewRecordAAAA(RecordAAAA{
+		Name:     recordname,
+		Ipv6Addr: ipAddr})
+	recordAAAA.TTL, recordAAAA.UseTTL = updateTTL(ttl)
+	if comment != nil {
+		recordAAAA.Comment = *comment
+	}
+
+	if len(ea) > 0 {
+		recordAAAA.Ea = objMgr.extendEA(ea)
+	}
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateComment(recordAAAA, comment), ref)
+	recordAAAA.Ref = newRef
+	return recordAAAA, err
+}
+
+// DeleteAAAARecord deletes the AAAA record with reference ref
+func (objMgr *ObjectManager) DeleteAAAARecord(ref string) (string, error) {
+	return objMgr.DeleteAAAARecordWithContext(context.Background(), ref)
+}
+
+// DeleteAAAARecordWithContext is like DeleteAAAARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteAAAARecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateCNAMERecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) CreateCNAMERecord(canonical string, recordname string, dnsview string, ea EA) (*RecordCNAME, error) {
+	return objMgr.CreateCNAMERecordWithContext(context.Background(), canonical, recordname, dnsview, ea)
+}
+
+// CreateCNAMERecordWithContext is like CreateCNAMERecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateCNAMERecordWithContext(ctx context.Context, canonical string, recordname string, dnsview string, ea EA) (*RecordCNAME, error) {
+
+	eas := objMgr.extendEA(ea)
+
+	recordCNAME := NewRecordCNAME(RecordCNAME{
+		View:      dnsview,
+		Name:      recordname,
+		Canonical: canonical,
+		Ea:        eas})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordCNAME)
+	recordCNAME.Ref = ref
+	return recordCNAME, err
+}
+
+// GetCNAMERecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetCNAMERecordByRef(ref string) (*RecordCNAME, error) {
+	return objMgr.GetCNAMERecordByRefWithContext(context.Background(), ref)
+}
+
+// GetCNAMERecordByRefWithContext is like GetCNAMERecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetCNAMERecordByRefWithContext(ctx context.Context, ref string) (*RecordCNAME, error) {
+	recordCNAME := NewRecordCNAME(RecordCNAME{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordCNAME, ref, &recordCNAME)
+	return recordCNAME, err
+}
+
+// GetCNAMERecords returns the CNAME records matching the view, name,
+// canonical name, zone and extensible attributes which are not empty, in
+// every view if dnsview is empty
+func (objMgr *ObjectManager) GetCNAMERecords(dnsview string, recordname string, canonical string, zone string, ea EA) ([]RecordCNAME, error) {
+	return objMgr.GetCNAMERecordsWithContext(context.Background(), dnsview, recordname, canonical, zone, ea)
+}
+
+// GetCNAMERecordsWithContext is like GetCNAMERecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetCNAMERecordsWithContext(ctx context.Context, dnsview string, recordname string, canonical string, zone string, ea EA) ([]RecordCNAME, error) {
+	var res []RecordCNAME
+
+	recordCNAME := NewRecordCNAME(RecordCNAME{
+		View:      dnsview,
+		Name:      recordname,
+		Canonical: canonical,
+		Zone:      zone})
+	if len(ea) > 0 {
+		recordCNAME.eaSearch = EASearch(ea)
+	}
+
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, recordCNAME, DefaultPageSize, &res)
+	return res, err
+}
+
+// UpdateCNAMERecord changes the CNAME record with reference ref. The
+// canonical name, name and extensible attributes are left unchanged if
+// empty, the TTL and comment if nil, a TTL of 0 inherits the one of the
+// Zone.
+func (objMgr *ObjectManager) UpdateCNAMERecord(ref string, canonical string, recordname string, ttl *uint32, comment *string, ea EA) (*RecordCNAME, error) {
+	return objMgr.UpdateCNAMERecordWithContext(context.Background(), ref, canonical, recordname, ttl, comment, ea)
+}
+
+// UpdateCNAMERecordWithContext is like UpdateCNAMERecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateCNAMERecordWithContext(ctx context.Context, ref string, canonical string, recordname string, ttl *uint32, comment *string, ea EA) (*RecordCNAME, error) {
+	recordCNAME := NewRecordCNAME(RecordCNAME{
+		Canonical: canonical,
+		Name:      recordname})
+	recordCNAME.TTL, recordCNAME.UseTTL = updateTTL(ttl)
+	if comment != nil {
+		recordCNAME.Comment = *comment
+	}
+
+	if len(ea) > 0 {
+		recordCNAME.Ea = objMgr.extendEA(ea)
+	}
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateComment(recordCNAME, comment), ref)
+	recordCNAME.Ref = newRef
+	return recordCNAME, err
+}
+
+// DeleteCNAMERecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) DeleteCNAMERecord(ref string) (string, error) {
+	return objMgr.DeleteCNAMERecordWithContext(context.Background(), ref)
+}
+
+// DeleteCNAMERecordWithContext is like DeleteCNAMERecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteCNAMERecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateTXTRecord creates TXT Record. Use TTL of 0 to inherit TTL from the Zone
+func (objMgr *ObjectManager) CreateTXTRecord(recordname string, text string, ttl int, dnsview string) (*RecordTXT, error) {
+	return objMgr.CreateTXTRecordWithContext(context.Background(), recordname, text, ttl, dnsview)
+}
+
+// CreateTXTRecordWithContext is like CreateTXTRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateTXTRecordWithContext(ctx context.Context, recordname string, text string, ttl int, dnsview string) (*RecordTXT, error) {
+
+	recordTXT := NewRecordTXT(RecordTXT{
+		View: dnsview,
+		Name: recordname,
+		Text: text,
+		TTL:  ttl,
+	})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordTXT)
+	recordTXT.Ref = ref
+	return recordTXT, err
+}
+
+// GetTXTRecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetTXTRecordByRef(ref string) (*RecordTXT, error) {
+	return objMgr.GetTXTRecordByRefWithContext(context.Background(), ref)
+}
+
+// GetTXTRecordByRefWithContext is like GetTXTRecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetTXTRecordByRefWithContext(ctx context.Context, ref string) (*RecordTXT, error) {
+	recordTXT := NewRecordTXT(RecordTXT{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordTXT, ref, &recordTXT)
+	return recordTXT, err
+}
+
+// GetTXTRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) GetTXTRecord(name string) (*RecordTXT, error) {
+	return objMgr.GetTXTRecordWithContext(context.Background(), name)
+}
+
+// GetTXTRecordWithContext is like GetTXTRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetTXTRecordWithContext(ctx context.Context, name string) (*RecordTXT, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name can not be empty")
+	}
+	var res []RecordTXT
+
+	recordTXT := NewRecordTXT(RecordTXT{Name: name})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordTXT, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateTXTRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) UpdateTXTRecord(recordname string, text string) (*RecordTXT, error) {
+	return objMgr.UpdateTXTRecordWithContext(context.Background(), recordname, text)
+}
+
+// UpdateTXTRecordWithContext is like UpdateTXTRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateTXTRecordWithContext(ctx context.Context, recordname string, text string) (*RecordTXT, error) {
+	var res []RecordTXT
+
+	recordTXT := NewRecordTXT(RecordTXT{Name: recordname})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordTXT, "", &res)
+
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+
+	res[0].Text = text
+
+	res[0].Zone = "" //  set the Zone value to "" as its a non writable field
+
+	_, err = objMgr.connector.UpdateObjectWithContext(ctx, &res[0], res[0].Ref)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// DeleteTXTRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
+func (objMgr *ObjectManager) DeleteTXTRecord(ref string) (string, error) {
+	return objMgr.DeleteTXTRecordWithContext(context.Background(), ref)
+}
+
+// DeleteTXTRecordWithContext is like DeleteTXTRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteTXTRecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// Uint32Ptr returns a pointer to v, for the numeric record fields whose
+// zero value is meaningful and the optional numeric arguments of updates
+func Uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+// BoolPtr returns a pointer to v, for the optional boolean arguments of
+// updates
+func BoolPtr(v bool) *bool {
+	return &v
+}
+
+// StringPtr returns a pointer to v, for the optional string arguments of
+// updates whose empty value is meaningful
+func StringPtr(v string) *string {
+	return &v
+}
+
+// recordTTL returns the ttl and use_ttl fields of a record, a ttl of 0
+// inherits the TTL of the zone
+func recordTTL(ttl uint32) (uint32, *bool) {
+	useTTL := ttl > 0
+	return ttl, &useTTL
+}
+
+// updateTTL is like recordTTL for updates, where a nil ttl leaves the TTL
+// unchanged
+func updateTTL(ttl *uint32) (uint32, *bool) {
+	if ttl == nil {
+		return 0, nil
+	}
+	return recordTTL(*ttl)
+}
+
+// clearedFields is the update of an object emptying some of its fields,
+// which the omitempty tags of the objects would not send
+type clearedFields struct {
+	IBObject
+	// empty holds the empty JSON value sent for each cleared field
+	empty map[string]json.RawMessage
+}
+
+// MarshalJSON adds the cleared fields to the fields of the object
+func (c clearedFields) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(c.IBObject)
+	if err != nil {
 		return nil, err
 	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range c.empty {
+		fields[name] = value
+	}
+	return json.Marshal(fields)
+}
+
+// clearField returns the update of obj which also sends field with the
+// empty JSON value
+func clearField(obj IBObject, field string, empty string) IBObject {
+	c, ok := obj.(clearedFields)
+	if !ok {
+		c = clearedFields{IBObject: obj, empty: make(map[string]json.RawMessage)}
+	}
+	c.empty[field] = json.RawMessage(empty)
+	return c
+}
+
+// updateComment returns the update of record, which sends an empty comment
+// if comment points to one
+func updateComment(record IBObject, comment *string) IBObject {
+	if comment != nil && *comment == "" {
+		return clearField(record, "comment", `""`)
+	}
+	return record
+}
+
+// updateOptions returns the update of obj, which removes its DHCP options
+// if options is empty but not nil
+func updateOptions(obj IBObject, options []DhcpOption) IBObject {
+	if options != nil && len(options) == 0 {
+		return clearField(obj, "options", `[]`)
+	}
+	return obj
+}
+
+// CreateMXRecord creates an MX record. Use TTL of 0 to inherit TTL from the Zone
+func (objMgr *ObjectManager) CreateMXRecord(dnsview string, recordname string, mailExchanger string, preference uint32, ttl uint32, ea EA) (*RecordMX, error) {
+	return objMgr.CreateMXRecordWithContext(context.Background(), dnsview, recordname, mailExchanger, preference, ttl, ea)
+}
+
+// CreateMXRecordWithContext is like CreateMXRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateMXRecordWithContext(ctx context.Context, dnsview string, recordname string, mailExchanger string, preference uint32, ttl uint32, ea EA) (*RecordMX, error) {
+	recordMX := NewRecordMX(RecordMX{
+		View:          dnsview,
+		Name:          recordname,
+		MailExchanger: mailExchanger,
+		Preference:    Uint32Ptr(preference),
+		Ea:            objMgr.extendEA(ea)})
+	recordMX.TTL, recordMX.UseTTL = recordTTL(ttl)
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordMX)
+	recordMX.Ref = ref
+	return recordMX, err
+}
+
+// GetMXRecordByRef returns the MX record with reference ref
+func (objMgr *ObjectManager) GetMXRecordByRef(ref string) (*RecordMX, error) {
+	return objMgr.GetMXRecordByRefWithContext(context.Background(), ref)
+}
+
+// GetMXRecordByRefWithContext is like GetMXRecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetMXRecordByRefWithContext(ctx context.Context, ref string) (*RecordMX, error) {
+	recordMX := NewRecordMX(RecordMX{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordMX, ref, &recordMX)
+	return recordMX, err
+}
+
+// GetMXRecords returns the MX records of dnsview matching the name and mail
+// exchanger which are not empty
+func (objMgr *ObjectManager) GetMXRecords(dnsview string, recordname string, mailExchanger string) ([]RecordMX, error) {
+	return objMgr.GetMXRecordsWithContext(context.Background(), dnsview, recordname, mailExchanger)
+}
+
+// GetMXRecordsWithContext is like GetMXRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetMXRecordsWithContext(ctx context.Context, dnsview string, recordname string, mailExchanger string) ([]RecordMX, error) {
+	var res []RecordMX
+
+	recordMX := NewRecordMX(RecordMX{
+		View:          dnsview,
+		Name:          recordname,
+		MailExchanger: mailExchanger})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordMX, "", &res)
+	return res, err
+}
+
+// UpdateMXRecord changes the MX record with reference ref. The name, mail
+// exchanger and extensible attributes are left unchanged if empty, the
+// preference and TTL if nil, a TTL of 0 inherits the one of the Zone.
+func (objMgr *ObjectManager) UpdateMXRecord(ref string, recordname string, mailExchanger string, preference *uint32, ttl *uint32, ea EA) (*RecordMX, error) {
+	return objMgr.UpdateMXRecordWithContext(context.Background(), ref, recordname, mailExchanger, preference, ttl, ea)
+}
+
+// UpdateMXRecordWithContext is like UpdateMXRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateMXRecordWithContext(ctx context.Context, ref string, recordname string, mailExchanger string, preference *uint32, ttl *uint32, ea EA) (*RecordMX, error) {
+	recordMX := NewRecordMX(RecordMX{
+		Name:          recordname,
+		MailExchanger: mailExchanger,
+		Preference:    preference})
+	recordMX.TTL, recordMX.UseTTL = updateTTL(ttl)
+
+	if len(ea) > 0 {
+		recordMX.Ea = objMgr.extendEA(ea)
+	}
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, recordMX, ref)
+	recordMX.Ref = newRef
+	return recordMX, err
+}
+
+// DeleteMXRecord deletes the MX record with reference ref
+func (objMgr *ObjectManager) DeleteMXRecord(ref string) (string, error) {
+	return objMgr.DeleteMXRecordWithContext(context.Background(), ref)
+}
+
+// DeleteMXRecordWithContext is like DeleteMXRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteMXRecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateSRVRecord creates an SRV record, recordname being e.g.
+// _ldap._tcp.example.com. Use TTL of 0 to inherit TTL from the Zone
+func (objMgr *ObjectManager) CreateSRVRecord(dnsview string, recordname string, target string, priority uint32, weight uint32, port uint32, ttl uint32, ea EA) (*RecordSRV, error) {
+	return objMgr.CreateSRVRecordWithContext(context.Background(), dnsview, recordname, target, priority, weight, port, ttl, ea)
+}
+
+// CreateSRVRecordWithContext is like CreateSRVRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateSRVRecordWithContext(ctx context.Context, dnsview string, recordname string, target string, priority uint32, weight uint32, port uint32, ttl uint32, ea EA) (*RecordSRV, error) {
+	recordSRV := NewRecordSRV(RecordSRV{
+		View:     dnsview,
+		Name:     recordname,
+		Target:   target,
+		Priority: Uint32Ptr(priority),
+		Weight:   Uint32Ptr(weight),
+		Port:     Uint32Ptr(port),
+		Ea:       objMgr.extendEA(ea)})
+	recordSRV.TTL, recordSRV.UseTTL = recordTTL(ttl)
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordSRV)
+	recordSRV.Ref = ref
+	return recordSRV, err
+}
+
+// GetSRVRecordByRef returns the SRV record with reference ref
+func (objMgr *ObjectManager) GetSRVRecordByRef(ref string) (*RecordSRV, error) {
+	return objMgr.GetSRVRecordByRefWithContext(context.Background(), ref)
+}
+
+// GetSRVRecordByRefWithContext is like GetSRVRecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetSRVRecordByRefWithContext(ctx context.Context, ref string) (*RecordSRV, error) {
+	recordSRV := NewRecordSRV(RecordSRV{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordSRV, ref, &recordSRV)
+	return recordSRV, err
+}
+
+// GetSRVRecords returns the SRV records of dnsview matching the name and
+// target which are not empty
+func (objMgr *ObjectManager) GetSRVRecords(dnsview string, recordname string, target string) ([]RecordSRV, error) {
+	return objMgr.GetSRVRecordsWithContext(context.Background(), dnsview, recordname, target)
+}
+
+// GetSRVRecordsWithContext is like GetSRVRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetSRVRecordsWithContext(ctx context.Context, dnsview string, recordname string, target string) ([]RecordSRV, error) {
+	var res []RecordSRV
+
+	recordSRV := NewRecordSRV(RecordSRV{
+		View:   dnsview,
+		Name:   recordname,
+		Target: target})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordSRV, "", &res)
+	return res, err
+}
+
+// UpdateSRVRecord changes the SRV record with reference ref. The name,
+// target and extensible attributes are left unchanged if empty, the
+// priority, weight, port and TTL if nil, a TTL of 0 inherits the one of the
+// Zone.
+func (objMgr *ObjectManager) UpdateSRVRecord(ref string, recordname string, target string, priority *uint32, weight *uint32, port *uint32, ttl *uint32, ea EA) (*RecordSRV, error) {
+	return objMgr.UpdateSRVRecordWithContext(context.Background(), ref, recordname, target, priority, weight, port, ttl, ea)
+}
+
+// UpdateSRVRecordWithContext is like UpdateSRVRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateSRVRecordWithContext(ctx context.Context, ref string, recordname string, target string, priority *uint32, weight *uint32, port *uint32, ttl *uint32, ea EA) (*RecordSRV, error) {
+	recordSRV := NewRecordSRV(RecordSRV{
+		Name:     recordname,
+		Target:   target,
+		Priority: priority,
+		Weight:   weight,
+		Port:     port})
+	recordSRV.TTL, recordSRV.UseTTL = updateTTL(ttl)
+
+	if len(ea) > 0 {
+		recordSRV.Ea = objMgr.extendEA(ea)
+	}
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, recordSRV, ref)
+	recordSRV.Ref = newRef
+	return recordSRV, err
+}
+
+// DeleteSRVRecord deletes the SRV record with reference ref
+func (objMgr *ObjectManager) DeleteSRVRecord(ref string) (string, error) {
+	return objMgr.DeleteSRVRecordWithContext(context.Background(), ref)
+}
+
+// DeleteSRVRecordWithContext is like DeleteSRVRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteSRVRecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateNSRecord creates an NS record delegating the zone recordname to the
+// name server nameserver, whose addresses are needed when it is inside the
+// zone. NS records have no TTL nor extensible attributes in WAPI.
+func (objMgr *ObjectManager) CreateNSRecord(dnsview string, recordname string, nameserver string, addresses []NSRecordAddress) (*RecordNS, error) {
+	return objMgr.CreateNSRecordWithContext(context.Background(), dnsview, recordname, nameserver, addresses)
+}
+
+// CreateNSRecordWithContext is like CreateNSRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateNSRecordWithContext(ctx context.Context, dnsview string, recordname string, nameserver string, addresses []NSRecordAddress) (*RecordNS, error) {
+	recordNS := NewRecordNS(RecordNS{
+		View:       dnsview,
+		Name:       recordname,
+		Nameserver: nameserver,
+		Addresses:  addresses})
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordNS)
+	recordNS.Ref = ref
+	return recordNS, err
+}
+
+// GetNSRecordByRef returns the NS record with reference ref
+func (objMgr *ObjectManager) GetNSRecordByRef(ref string) (*RecordNS, error) {
+	return objMgr.GetNSRecordByRefWithContext(context.Background(), ref)
+}
+
+// GetNSRecordByRefWithContext is like GetNSRecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNSRecordByRefWithContext(ctx context.Context, ref string) (*RecordNS, error) {
+	recordNS := NewRecordNS(RecordNS{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordNS, ref, &recordNS)
+	return recordNS, err
+}
+
+// GetNSRecords returns the NS records of dnsview matching the name and name
+// server which are not empty
+func (objMgr *ObjectManager) GetNSRecords(dnsview string, recordname string, nameserver string) ([]RecordNS, error) {
+	return objMgr.GetNSRecordsWithContext(context.Background(), dnsview, recordname, nameserver)
+}
+
+// GetNSRecordsWithContext is like GetNSRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetNSRecordsWithContext(ctx context.Context, dnsview string, recordname string, nameserver string) ([]RecordNS, error) {
+	var res []RecordNS
+
+	recordNS := NewRecordNS(RecordNS{
+		View:       dnsview,
+		Name:       recordname,
+		Nameserver: nameserver})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordNS, "", &res)
+	return res, err
+}
+
+// UpdateNSRecord changes the name server and addresses of the NS record
+// with reference ref, those which are empty are left unchanged
+func (objMgr *ObjectManager) UpdateNSRecord(ref string, nameserver string, addresses []NSRecordAddress) (*RecordNS, error) {
+	return objMgr.UpdateNSRecordWithContext(context.Background(), ref, nameserver, addresses)
+}
+
+// UpdateNSRecordWithContext is like UpdateNSRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateNSRecordWithContext(ctx context.Context, ref string, nameserver string, addresses []NSRecordAddress) (*RecordNS, error) {
+	recordNS := NewRecordNS(RecordNS{
+		Nameserver: nameserver,
+		Addresses:  addresses})
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, recordNS, ref)
+	recordNS.Ref = newRef
+	return recordNS, err
+}
+
+// DeleteNSRecord deletes the NS record with reference ref
+func (objMgr *ObjectManager) DeleteNSRecord(ref string) (string, error) {
+	return objMgr.DeleteNSRecordWithContext(context.Background(), ref)
+}
+
+// DeleteNSRecordWithContext is like DeleteNSRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteNSRecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// CreateCAARecord creates a CAA record, e.g. with tag "issue" and value
+// "letsencrypt.org". Use TTL of 0 to inherit TTL from the Zone
+func (objMgr *ObjectManager) CreateCAARecord(dnsview string, recordname string, flag uint32, tag string, value string, ttl uint32, ea EA) (*RecordCAA, error) {
+	return objMgr.CreateCAARecordWithContext(context.Background(), dnsview, recordname, flag, tag, value, ttl, ea)
+}
+
+// CreateCAARecordWithContext is like CreateCAARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateCAARecordWithContext(ctx context.Context, dnsview string, recordname string, flag uint32, tag string, value string, ttl uint32, ea EA) (*RecordCAA, error) {
+	recordCAA := NewRecordCAA(RecordCAA{
+		View:    dnsview,
+		Name:    recordname,
+		CAFlag:  Uint32Ptr(flag),
+		CATag:   tag,
+		CAValue: value,
+		Ea:      objMgr.extendEA(ea)})
+	recordCAA.TTL, recordCAA.UseTTL = recordTTL(ttl)
+
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordCAA)
+	recordCAA.Ref = ref
+	return recordCAA, err
+}
+
+// GetCAARecordByRef returns the CAA record with reference ref
+func (objMgr *ObjectManager) GetCAARecordByRef(ref string) (*RecordCAA, error) {
+	return objMgr.GetCAARecordByRefWithContext(context.Background(), ref)
+}
+
+// GetCAARecordByRefWithContext is like GetCAARecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetCAARecordByRefWithContext(ctx context.Context, ref string) (*RecordCAA, error) {
+	recordCAA := NewRecordCAA(RecordCAA{})
+	err := objMgr.connector.GetObjectWithContext(ctx, recordCAA, ref, &recordCAA)
+	return recordCAA, err
+}
+
+// GetCAARecords returns the CAA records of dnsview matching the name and
+// tag which are not empty
+func (objMgr *ObjectManager) GetCAARecords(dnsview string, recordname string, tag string) ([]RecordCAA, error) {
+	return objMgr.GetCAARecordsWithContext(context.Background(), dnsview, recordname, tag)
+}
+
+// GetCAARecordsWithContext is like GetCAARecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetCAARecordsWithContext(ctx context.Context, dnsview string, recordname string, tag string) ([]RecordCAA, error) {
+	var res []RecordCAA
+
+	recordCAA := NewRecordCAA(RecordCAA{
+		View:  dnsview,
+		Name:  recordname,
+		CATag: tag})
+
+	err := objMgr.connector.GetObjectWithContext(ctx, recordCAA, "", &res)
+	return res, err
+}
+
+// UpdateCAARecord changes the CAA record with reference ref. The name, tag,
+// value and extensible attributes are left unchanged if empty, the flag and
+// TTL if nil, a TTL of 0 inherits the one of the Zone.
+func (objMgr *ObjectManager) UpdateCAARecord(ref string, recordname string, flag *uint32, tag string, value string, ttl *uint32, ea EA) (*RecordCAA, error) {
+	return objMgr.UpdateCAARecordWithContext(context.Background(), ref, recordname, flag, tag, value, ttl, ea)
+}
+
+// UpdateCAARecordWithContext is like UpdateCAARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateCAARecordWithContext(ctx context.Context, ref string, recordname string, flag *uint32, tag string, value string, ttl *uint32, ea EA) (*RecordCAA, error) {
+	recordCAA := NewRecordCAA(RecordCAA{
+		Name:    recordname,
+		CAFlag:  flag,
+		CATag:   tag,
+		CAValue: value})
+	recordCAA.TTL, recordCAA.UseTTL = updateTTL(ttl)
+
+	if len(ea) > 0 {
+		recordCAA.Ea = objMgr.extendEA(ea)
+	}
 
-	return &res[0], nil
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, recordCAA, ref)
+	recordCAA.Ref = newRef
+	return recordCAA, err
 }
 
-// DeleteTXTRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
-func (objMgr *ObjectManager) DeleteTXTRecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+// DeleteCAARecord deletes the CAA record with reference ref
+func (objMgr *ObjectManager) DeleteCAARecord(ref string) (string, error) {
+	return objMgr.DeleteCAARecordWithContext(context.Background(), ref)
+}
+
+// DeleteCAARecordWithContext is like DeleteCAARecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteCAARecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
 }
 
 // CreatePTRRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) CreatePTRRecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordPTR, error) {
+	return objMgr.CreatePTRRecordWithContext(context.Background(), netview, dnsview, recordname, cidr, ipAddr, ea)
+}
+
+// CreatePTRRecordWithContext is like CreatePTRRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreatePTRRecordWithContext(ctx context.Context, netview string, dnsview string, recordname string, cidr string, ipAddr string, ea EA) (*RecordPTR, error) {
 
 	eas := objMgr.extendEA(ea)
 
@@ -701,29 +2574,130 @@ func (objMgr *ObjectManager) CreatePTRRecord(netview string, dnsview string, rec
 	} else {
 		recordPTR.Ipv4Addr = ipAddr
 	}
-	ref, err := objMgr.connector.CreateObject(recordPTR)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, recordPTR)
 	recordPTR.Ref = ref
 	return recordPTR, err
 }
 
 // GetPTRRecordByRef https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) GetPTRRecordByRef(ref string) (*RecordPTR, error) {
+	return objMgr.GetPTRRecordByRefWithContext(context.Background(), ref)
+}
+
+// GetPTRRecordByRefWithContext is like GetPTRRecordByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetPTRRecordByRefWithContext(ctx context.Context, ref string) (*RecordPTR, error) {
 	recordPTR := NewRecordPTR(RecordPTR{})
-	err := objMgr.connector.GetObject(recordPTR, ref, &recordPTR)
+	err := objMgr.connector.GetObjectWithContext(ctx, recordPTR, ref, &recordPTR)
+	return recordPTR, err
+}
+
+// GetPTRRecords returns the PTR records matching the view, domain name
+// they point to, address, zone and extensible attributes which are not
+// empty, in every view if dnsview is empty
+func (objMgr *ObjectManager) GetPTRRecords(dnsview string, ptrdname string, ipAddr string, zone string, ea EA) ([]RecordPTR, error) {
+	return objMgr.GetPTRRecordsWithContext(context.Background(), dnsview, ptrdname, ipAddr, zone, ea)
+}
+
+// GetPTRRecordsWithContext is like GetPTRRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetPTRRecordsWithContext(ctx context.Context, dnsview string, ptrdname string, ipAddr string, zone string, ea EA) ([]RecordPTR, error) {
+	var res []RecordPTR
+
+	recordPTR := NewRecordPTR(RecordPTR{
+		View:     dnsview,
+		PtrdName: ptrdname,
+		Ipv4Addr: ipAddr,
+		Zone:     zone})
+	if len(ea) > 0 {
+		recordPTR.eaSearch = EASearch(ea)
+	}
+
+	err := GetAllObjectsWithContext(ctx, objMgr.connector, recordPTR, DefaultPageSize, &res)
+	return res, err
+}
+
+// GetRecordsByIP returns the A and PTR records of the IPv4 address ipAddr
+// in every view, IPv6 addresses are rejected with an error
+func (objMgr *ObjectManager) GetRecordsByIP(ipAddr string) ([]RecordA, []RecordPTR, error) {
+	return objMgr.GetRecordsByIPWithContext(context.Background(), ipAddr)
+}
+
+// GetRecordsByIPWithContext is like GetRecordsByIP but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetRecordsByIPWithContext(ctx context.Context, ipAddr string) ([]RecordA, []RecordPTR, error) {
+	if ip := net.ParseIP(ipAddr); ip == nil || ip.To4() == nil {
+		return nil, nil, fmt.Errorf("invalid IPv4 address %q", ipAddr)
+	}
+	recordsA, err := objMgr.GetARecordsWithContext(ctx, "", "", ipAddr, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	recordsPTR, err := objMgr.GetPTRRecordsWithContext(ctx, "", "", ipAddr, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return recordsA, recordsPTR, nil
+}
+
+// UpdatePTRRecord changes the PTR record with reference ref. The domain
+// name it points to, its own name, address and extensible attributes are
+// left unchanged if empty, the TTL and comment if nil, a TTL of 0 inherits
+// the one of the Zone.
+func (objMgr *ObjectManager) UpdatePTRRecord(ref string, ptrdname string, recordname string, ipAddr string, ttl *uint32, comment *string, ea EA) (*RecordPTR, error) {
+	return objMgr.UpdatePTRRecordWithContext(context.Background(), ref, ptrdname, recordname, ipAddr, ttl, comment, ea)
+}
+
+// UpdatePTRRecordWithContext is like UpdatePTRRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdatePTRRecordWithContext(ctx context.Context, ref string, ptrdname string, recordname string, ipAddr string, ttl *uint32, comment *string, ea EA) (*RecordPTR, error) {
+	recordPTR := NewRecordPTR(RecordPTR{
+		PtrdName: ptrdname,
+		Name:     recordname,
+		Ipv4Addr: ipAddr})
+	recordPTR.TTL, recordPTR.UseTTL = updateTTL(ttl)
+	if comment != nil {
+		recordPTR.Comment = *comment
+	}
+
+	if len(ea) > 0 {
+		recordPTR.Ea = objMgr.extendEA(ea)
+	}
+
+	newRef, err := objMgr.connector.UpdateObjectWithContext(ctx, updateComment(recordPTR, comment), ref)
+	recordPTR.Ref = newRef
 	return recordPTR, err
 }
 
 // DeletePTRRecord https://github.com/infobloxopen/infoblox-swagger-wapi/issues/1
 func (objMgr *ObjectManager) DeletePTRRecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.DeletePTRRecordWithContext(context.Background(), ref)
+}
+
+// DeletePTRRecordWithContext is like DeletePTRRecord but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeletePTRRecordWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// MultiObjectCreator is implemented by connectors other than Connector, such
+// as test doubles, which can send a MultiRequest
+type MultiObjectCreator interface {
+	CreateMultiObjectWithContext(ctx context.Context, req *MultiRequest) ([]map[string]interface{}, error)
 }
 
 // CreateMultiObject unmarshals the result into slice of maps
 func (objMgr *ObjectManager) CreateMultiObject(req *MultiRequest) ([]map[string]interface{}, error) {
+	return objMgr.CreateMultiObjectWithContext(context.Background(), req)
+}
+
+// CreateMultiObjectWithContext is like CreateMultiObject but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateMultiObjectWithContext(ctx context.Context, req *MultiRequest) ([]map[string]interface{}, error) {
 
-	conn := objMgr.connector.(*Connector)
+	if mc, ok := objMgr.connector.(MultiObjectCreator); ok {
+		return mc.CreateMultiObjectWithContext(ctx, req)
+	}
+	conn, ok := objMgr.connector.(*Connector)
+	if !ok {
+		return nil, fmt.Errorf("connector %T cannot send multiple object requests", objMgr.connector)
+	}
 	queryParams := QueryParams{forceProxy: false}
-	res, err := conn.makeRequest(CREATE, req, "", queryParams)
+	res, err := conn.makeRequest(ctx, CREATE, req, "", queryParams)
 
 	if err != nil {
 		return nil, err
@@ -733,6 +2707,7 @@ func (objMgr *ObjectManager) CreateMultiObject(req *MultiRequest) ([]map[string]
 	err = json.Unmarshal(res, &result)
 
 	if err != nil {
+		conn.logUnmarshalError(req.ObjectType(), res, err)
 		return nil, err
 	}
 
@@ -741,6 +2716,11 @@ func (objMgr *ObjectManager) CreateMultiObject(req *MultiRequest) ([]map[string]
 
 // GetUpgradeStatus returns the grid upgrade information
 func (objMgr *ObjectManager) GetUpgradeStatus(statusType string) ([]UpgradeStatus, error) {
+	return objMgr.GetUpgradeStatusWithContext(context.Background(), statusType)
+}
+
+// GetUpgradeStatusWithContext is like GetUpgradeStatus but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetUpgradeStatusWithContext(ctx context.Context, statusType string) ([]UpgradeStatus, error) {
 	var res []UpgradeStatus
 
 	if statusType == "" {
@@ -750,59 +2730,89 @@ func (objMgr *ObjectManager) GetUpgradeStatus(statusType string) ([]UpgradeStatu
 		return res, errors.New(msg)
 	}
 	upgradestatus := NewUpgradeStatus(UpgradeStatus{Type: statusType})
-	err := objMgr.connector.GetObject(upgradestatus, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, upgradestatus, "", &res)
 
 	return res, err
 }
 
 // GetAllMembers returns all members information
 func (objMgr *ObjectManager) GetAllMembers() ([]Member, error) {
+	return objMgr.GetAllMembersWithContext(context.Background())
+}
+
+// GetAllMembersWithContext is like GetAllMembers but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetAllMembersWithContext(ctx context.Context) ([]Member, error) {
 	var res []Member
 
 	memberObj := NewMember(Member{})
-	err := objMgr.connector.GetObject(memberObj, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, memberObj, "", &res)
 	return res, err
 }
 
 // GetCapacityReport returns all capacity for members
 func (objMgr *ObjectManager) GetCapacityReport(name string) ([]CapacityReport, error) {
+	return objMgr.GetCapacityReportWithContext(context.Background(), name)
+}
+
+// GetCapacityReportWithContext is like GetCapacityReport but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetCapacityReportWithContext(ctx context.Context, name string) ([]CapacityReport, error) {
 	var res []CapacityReport
 
 	capacityObj := CapacityReport{Name: name}
 	capacityReport := NewCapcityReport(capacityObj)
-	err := objMgr.connector.GetObject(capacityReport, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, capacityReport, "", &res)
 	return res, err
 }
 
 // GetLicense returns the license details for member
 func (objMgr *ObjectManager) GetLicense() ([]License, error) {
+	return objMgr.GetLicenseWithContext(context.Background())
+}
+
+// GetLicenseWithContext is like GetLicense but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetLicenseWithContext(ctx context.Context) ([]License, error) {
 	var res []License
 
 	licenseObj := NewLicense(License{})
-	err := objMgr.connector.GetObject(licenseObj, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, licenseObj, "", &res)
 	return res, err
 }
 
 // GetGridLicense returns the license details for grid
 func (objMgr *ObjectManager) GetGridLicense() ([]License, error) {
+	return objMgr.GetGridLicenseWithContext(context.Background())
+}
+
+// GetGridLicenseWithContext is like GetGridLicense but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetGridLicenseWithContext(ctx context.Context) ([]License, error) {
 	var res []License
 
 	licenseObj := NewGridLicense(License{})
-	err := objMgr.connector.GetObject(licenseObj, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, licenseObj, "", &res)
 	return res, err
 }
 
 // GetGridInfo returns the details for grid
 func (objMgr *ObjectManager) GetGridInfo() ([]Grid, error) {
+	return objMgr.GetGridInfoWithContext(context.Background())
+}
+
+// GetGridInfoWithContext is like GetGridInfo but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetGridInfoWithContext(ctx context.Context) ([]Grid, error) {
 	var res []Grid
 
 	gridObj := NewGrid(Grid{})
-	err := objMgr.connector.GetObject(gridObj, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, gridObj, "", &res)
 	return res, err
 }
 
 // CreateZoneAuth creates zones and subs by passing fqdn
 func (objMgr *ObjectManager) CreateZoneAuth(fqdn string, ea EA) (*ZoneAuth, error) {
+	return objMgr.CreateZoneAuthWithContext(context.Background(), fqdn, ea)
+}
+
+// CreateZoneAuthWithContext is like CreateZoneAuth but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateZoneAuthWithContext(ctx context.Context, fqdn string, ea EA) (*ZoneAuth, error) {
 
 	eas := objMgr.extendEA(ea)
 
@@ -810,13 +2820,18 @@ func (objMgr *ObjectManager) CreateZoneAuth(fqdn string, ea EA) (*ZoneAuth, erro
 		Fqdn: fqdn,
 		Ea:   eas})
 
-	ref, err := objMgr.connector.CreateObject(zoneAuth)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, zoneAuth)
 	zoneAuth.Ref = ref
 	return zoneAuth, err
 }
 
 // GetZoneAuthByRef retreives an authortative zone by ref
 func (objMgr *ObjectManager) GetZoneAuthByRef(ref string) (ZoneAuth, error) {
+	return objMgr.GetZoneAuthByRefWithContext(context.Background(), ref)
+}
+
+// GetZoneAuthByRefWithContext is like GetZoneAuthByRef but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetZoneAuthByRefWithContext(ctx context.Context, ref string) (ZoneAuth, error) {
 	var res ZoneAuth
 
 	if ref == "" {
@@ -824,27 +2839,42 @@ func (objMgr *ObjectManager) GetZoneAuthByRef(ref string) (ZoneAuth, error) {
 	}
 	zoneAuth := NewZoneAuth(ZoneAuth{})
 
-	err := objMgr.connector.GetObject(zoneAuth, ref, &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, zoneAuth, ref, &res)
 	return res, err
 }
 
 // DeleteZoneAuth deletes an auth zone
 func (objMgr *ObjectManager) DeleteZoneAuth(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.DeleteZoneAuthWithContext(context.Background(), ref)
+}
+
+// DeleteZoneAuthWithContext is like DeleteZoneAuth but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteZoneAuthWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
 }
 
 // GetZoneAuth returns the authoritatives zones
 func (objMgr *ObjectManager) GetZoneAuth() ([]ZoneAuth, error) {
+	return objMgr.GetZoneAuthWithContext(context.Background())
+}
+
+// GetZoneAuthWithContext is like GetZoneAuth but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetZoneAuthWithContext(ctx context.Context) ([]ZoneAuth, error) {
 	var res []ZoneAuth
 
 	zoneAuth := NewZoneAuth(ZoneAuth{})
-	err := objMgr.connector.GetObject(zoneAuth, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, zoneAuth, "", &res)
 
 	return res, err
 }
 
 // GetZoneDelegated returns the delegated zone
 func (objMgr *ObjectManager) GetZoneDelegated(fqdn string) (*ZoneDelegated, error) {
+	return objMgr.GetZoneDelegatedWithContext(context.Background(), fqdn)
+}
+
+// GetZoneDelegatedWithContext is like GetZoneDelegated but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) GetZoneDelegatedWithContext(ctx context.Context, fqdn string) (*ZoneDelegated, error) {
 	if len(fqdn) == 0 {
 		return nil, nil
 	}
@@ -852,7 +2882,7 @@ func (objMgr *ObjectManager) GetZoneDelegated(fqdn string) (*ZoneDelegated, erro
 
 	zoneDelegated := NewZoneDelegated(ZoneDelegated{Fqdn: fqdn})
 
-	err := objMgr.connector.GetObject(zoneDelegated, "", &res)
+	err := objMgr.connector.GetObjectWithContext(ctx, zoneDelegated, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -863,11 +2893,16 @@ func (objMgr *ObjectManager) GetZoneDelegated(fqdn string) (*ZoneDelegated, erro
 
 // CreateZoneDelegated creates delegated zone
 func (objMgr *ObjectManager) CreateZoneDelegated(fqdn string, delegateTo []NameServer) (*ZoneDelegated, error) {
+	return objMgr.CreateZoneDelegatedWithContext(context.Background(), fqdn, delegateTo)
+}
+
+// CreateZoneDelegatedWithContext is like CreateZoneDelegated but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) CreateZoneDelegatedWithContext(ctx context.Context, fqdn string, delegateTo []NameServer) (*ZoneDelegated, error) {
 	zoneDelegated := NewZoneDelegated(ZoneDelegated{
 		Fqdn:       fqdn,
 		DelegateTo: delegateTo})
 
-	ref, err := objMgr.connector.CreateObject(zoneDelegated)
+	ref, err := objMgr.connector.CreateObjectWithContext(ctx, zoneDelegated)
 	zoneDelegated.Ref = ref
 
 	return zoneDelegated, err
@@ -875,16 +2910,332 @@ func (objMgr *ObjectManager) CreateZoneDelegated(fqdn string, delegateTo []NameS
 
 // UpdateZoneDelegated updates delegated zone
 func (objMgr *ObjectManager) UpdateZoneDelegated(ref string, delegateTo []NameServer) (*ZoneDelegated, error) {
+	return objMgr.UpdateZoneDelegatedWithContext(context.Background(), ref, delegateTo)
+}
+
+// UpdateZoneDelegatedWithContext is like UpdateZoneDelegated but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) UpdateZoneDelegatedWithContext(ctx context.Context, ref string, delegateTo []NameServer) (*ZoneDelegated, error) {
 	zoneDelegated := NewZoneDelegated(ZoneDelegated{
 		Ref:        ref,
 		DelegateTo: delegateTo})
 
-	refResp, err := objMgr.connector.UpdateObject(zoneDelegated, ref)
+	refResp, err := objMgr.connector.UpdateObjectWithContext(ctx, zoneDelegated, ref)
 	zoneDelegated.Ref = refResp
 	return zoneDelegated, err
 }
 
 // DeleteZoneDelegated deletes delegated zone
 func (objMgr *ObjectManager) DeleteZoneDelegated(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.DeleteZoneDelegatedWithContext(context.Background(), ref)
+}
+
+// DeleteZoneDelegatedWithContext is like DeleteZoneDelegated but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) DeleteZoneDelegatedWithContext(ctx context.Context, ref string) (string, error) {
+	return objMgr.connector.DeleteObjectWithContext(ctx, ref)
+}
+
+// SearchNetworkViews returns the network views matching q
+func (objMgr *ObjectManager) SearchNetworkViews(q *Query) ([]NetworkView, error) {
+	return objMgr.SearchNetworkViewsWithContext(context.Background(), q)
+}
+
+// SearchNetworkViewsWithContext is like SearchNetworkViews but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchNetworkViewsWithContext(ctx context.Context, q *Query) ([]NetworkView, error) {
+	var res []NetworkView
+
+	obj := NewNetworkView(NetworkView{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchNetworks returns the networks matching q
+func (objMgr *ObjectManager) SearchNetworks(q *Query) ([]Network, error) {
+	return objMgr.SearchNetworksWithContext(context.Background(), q)
+}
+
+// SearchNetworksWithContext is like SearchNetworks but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchNetworksWithContext(ctx context.Context, q *Query) ([]Network, error) {
+	var res []Network
+
+	obj := NewNetwork(Network{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchNetworkContainers returns the network containers matching q
+func (objMgr *ObjectManager) SearchNetworkContainers(q *Query) ([]NetworkContainer, error) {
+	return objMgr.SearchNetworkContainersWithContext(context.Background(), q)
+}
+
+// SearchNetworkContainersWithContext is like SearchNetworkContainers but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchNetworkContainersWithContext(ctx context.Context, q *Query) ([]NetworkContainer, error) {
+	var res []NetworkContainer
+
+	obj := NewNetworkContainer(NetworkContainer{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchFixedAddresses returns the fixed addresses matching q
+func (objMgr *ObjectManager) SearchFixedAddresses(q *Query) ([]FixedAddress, error) {
+	return objMgr.SearchFixedAddressesWithContext(context.Background(), q)
+}
+
+// SearchFixedAddressesWithContext is like SearchFixedAddresses but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchFixedAddressesWithContext(ctx context.Context, q *Query) ([]FixedAddress, error) {
+	var res []FixedAddress
+
+	obj := NewFixedAddress(FixedAddress{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchRanges returns the DHCP ranges matching q
+func (objMgr *ObjectManager) SearchRanges(q *Query) ([]Range, error) {
+	return objMgr.SearchRangesWithContext(context.Background(), q)
+}
+
+// SearchRangesWithContext is like SearchRanges but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchRangesWithContext(ctx context.Context, q *Query) ([]Range, error) {
+	var res []Range
+
+	obj := NewRange(Range{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchLeases returns the DHCP leases matching q
+func (objMgr *ObjectManager) SearchLeases(q *Query) ([]Lease, error) {
+	return objMgr.SearchLeasesWithContext(context.Background(), q)
+}
+
+// SearchLeasesWithContext is like SearchLeases but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchLeasesWithContext(ctx context.Context, q *Query) ([]Lease, error) {
+	var res []Lease
+
+	obj := NewLease(Lease{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchIPv4Addresses returns the IPv4 addresses matching q, which must
+// search a network or ip_address
+func (objMgr *ObjectManager) SearchIPv4Addresses(q *Query) ([]IPv4Address, error) {
+	return objMgr.SearchIPv4AddressesWithContext(context.Background(), q)
+}
+
+// SearchIPv4AddressesWithContext is like SearchIPv4Addresses but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchIPv4AddressesWithContext(ctx context.Context, q *Query) ([]IPv4Address, error) {
+	var res []IPv4Address
+
+	obj := NewIPv4Address(IPv4Address{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchIPv6Addresses returns the IPv6 addresses matching q, which must
+// search a network or ip_address
+func (objMgr *ObjectManager) SearchIPv6Addresses(q *Query) ([]IPv6Address, error) {
+	return objMgr.SearchIPv6AddressesWithContext(context.Background(), q)
+}
+
+// SearchIPv6AddressesWithContext is like SearchIPv6Addresses but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchIPv6AddressesWithContext(ctx context.Context, q *Query) ([]IPv6Address, error) {
+	var res []IPv6Address
+
+	obj := NewIPv6Address(IPv6Address{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchHostRecords returns the host records matching q
+func (objMgr *ObjectManager) SearchHostRecords(q *Query) ([]HostRecord, error) {
+	return objMgr.SearchHostRecordsWithContext(context.Background(), q)
+}
+
+// SearchHostRecordsWithContext is like SearchHostRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchHostRecordsWithContext(ctx context.Context, q *Query) ([]HostRecord, error) {
+	var res []HostRecord
+
+	obj := NewHostRecord(HostRecord{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchARecords returns the A records matching q
+func (objMgr *ObjectManager) SearchARecords(q *Query) ([]RecordA, error) {
+	return objMgr.SearchARecordsWithContext(context.Background(), q)
+}
+
+// SearchARecordsWithContext is like SearchARecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchARecordsWithContext(ctx context.Context, q *Query) ([]RecordA, error) {
+	var res []RecordA
+
+	obj := NewRecordA(RecordA{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchAAAARecords returns the AAAA records matching q
+func (objMgr *ObjectManager) SearchAAAARecords(q *Query) ([]RecordAAAA, error) {
+	return objMgr.SearchAAAARecordsWithContext(context.Background(), q)
+}
+
+// SearchAAAARecordsWithContext is like SearchAAAARecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchAAAARecordsWithContext(ctx context.Context, q *Query) ([]RecordAAAA, error) {
+	var res []RecordAAAA
+
+	obj := NewRecordAAAA(RecordAAAA{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchPTRRecords returns the PTR records matching q
+func (objMgr *ObjectManager) SearchPTRRecords(q *Query) ([]RecordPTR, error) {
+	return objMgr.SearchPTRRecordsWithContext(context.Background(), q)
+}
+
+// SearchPTRRecordsWithContext is like SearchPTRRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchPTRRecordsWithContext(ctx context.Context, q *Query) ([]RecordPTR, error) {
+	var res []RecordPTR
+
+	obj := NewRecordPTR(RecordPTR{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchCNAMERecords returns the CNAME records matching q
+func (objMgr *ObjectManager) SearchCNAMERecords(q *Query) ([]RecordCNAME, error) {
+	return objMgr.SearchCNAMERecordsWithContext(context.Background(), q)
+}
+
+// SearchCNAMERecordsWithContext is like SearchCNAMERecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchCNAMERecordsWithContext(ctx context.Context, q *Query) ([]RecordCNAME, error) {
+	var res []RecordCNAME
+
+	obj := NewRecordCNAME(RecordCNAME{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchTXTRecords returns the TXT records matching q
+func (objMgr *ObjectManager) SearchTXTRecords(q *Query) ([]RecordTXT, error) {
+	return objMgr.SearchTXTRecordsWithContext(context.Background(), q)
+}
+
+// SearchTXTRecordsWithContext is like SearchTXTRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchTXTRecordsWithContext(ctx context.Context, q *Query) ([]RecordTXT, error) {
+	var res []RecordTXT
+
+	obj := NewRecordTXT(RecordTXT{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchMXRecords returns the MX records matching q
+func (objMgr *ObjectManager) SearchMXRecords(q *Query) ([]RecordMX, error) {
+	return objMgr.SearchMXRecordsWithContext(context.Background(), q)
+}
+
+// SearchMXRecordsWithContext is like SearchMXRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchMXRecordsWithContext(ctx context.Context, q *Query) ([]RecordMX, error) {
+	var res []RecordMX
+
+	obj := NewRecordMX(RecordMX{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchSRVRecords returns the SRV records matching q
+func (objMgr *ObjectManager) SearchSRVRecords(q *Query) ([]RecordSRV, error) {
+	return objMgr.SearchSRVRecordsWithContext(context.Background(), q)
+}
+
+// SearchSRVRecordsWithContext is like SearchSRVRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchSRVRecordsWithContext(ctx context.Context, q *Query) ([]RecordSRV, error) {
+	var res []RecordSRV
+
+	obj := NewRecordSRV(RecordSRV{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchNSRecords returns the NS records matching q
+func (objMgr *ObjectManager) SearchNSRecords(q *Query) ([]RecordNS, error) {
+	return objMgr.SearchNSRecordsWithContext(context.Background(), q)
+}
+
+// SearchNSRecordsWithContext is like SearchNSRecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchNSRecordsWithContext(ctx context.Context, q *Query) ([]RecordNS, error) {
+	var res []RecordNS
+
+	obj := NewRecordNS(RecordNS{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchCAARecords returns the CAA records matching q
+func (objMgr *ObjectManager) SearchCAARecords(q *Query) ([]RecordCAA, error) {
+	return objMgr.SearchCAARecordsWithContext(context.Background(), q)
+}
+
+// SearchCAARecordsWithContext is like SearchCAARecords but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchCAARecordsWithContext(ctx context.Context, q *Query) ([]RecordCAA, error) {
+	var res []RecordCAA
+
+	obj := NewRecordCAA(RecordCAA{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
+}
+
+// SearchZoneAuths returns the authoritative zones matching q
+func (objMgr *ObjectManager) SearchZoneAuths(q *Query) ([]ZoneAuth, error) {
+	return objMgr.SearchZoneAuthsWithContext(context.Background(), q)
+}
+
+// SearchZoneAuthsWithContext is like SearchZoneAuths but uses ctx for the WAPI requests.
+func (objMgr *ObjectManager) SearchZoneAuthsWithContext(ctx context.Context, q *Query) ([]ZoneAuth, error) {
+	var res []ZoneAuth
+
+	obj := NewZoneAuth(ZoneAuth{})
+	obj.query = q
+	err := objMgr.connector.GetObjectWithContext(ctx, obj, "", &res)
+
+	return res, err
 }
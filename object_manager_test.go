@@ -1,5 +1,13 @@
 package ibclient
 
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
 type fakeConnector struct {
 	createObjectObj interface{}
 
@@ -17,12 +25,20 @@ type fakeConnector struct {
 }
 
 func (c *fakeConnector) CreateObject(obj IBObject) (string, error) {
+	return c.CreateObjectWithContext(context.Background(), obj)
+}
+
+func (c *fakeConnector) CreateObjectWithContext(ctx context.Context, obj IBObject) (string, error) {
 	// Expect(obj).To(Equal(c.createObjectObj))
 
 	return c.fakeRefReturn, nil
 }
 
 func (c *fakeConnector) GetObject(obj IBObject, ref string, res interface{}) (err error) {
+	return c.GetObjectWithContext(context.Background(), obj, ref, res)
+}
+
+func (c *fakeConnector) GetObjectWithContext(ctx context.Context, obj IBObject, ref string, res interface{}) (err error) {
 	// Expect(obj).To(Equal(c.getObjectObj))
 	// Expect(ref).To(Equal(c.getObjectRef))
 
@@ -67,18 +83,36 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, res interface{}) (er
 }
 
 func (c *fakeConnector) DeleteObject(ref string) (string, error) {
+	return c.DeleteObjectWithContext(context.Background(), ref)
+}
+
+func (c *fakeConnector) DeleteObjectWithContext(ctx context.Context, ref string) (string, error) {
 	// Expect(ref).To(Equal(c.deleteObjectRef))
 
 	return c.fakeRefReturn, nil
 }
 
 func (c *fakeConnector) UpdateObject(obj IBObject, ref string) (string, error) {
+	return c.UpdateObjectWithContext(context.Background(), obj, ref)
+}
+
+func (c *fakeConnector) UpdateObjectWithContext(ctx context.Context, obj IBObject, ref string) (string, error) {
 	// Expect(obj).To(Equal(c.updateObjectObj))
 	// Expect(ref).To(Equal(c.updateObjectRef))
 
 	return c.fakeRefReturn, nil
 }
 
+func (c *fakeConnector) GetObjectPage(obj IBObject, pageID string, maxResults int, res interface{}) (string, error) {
+	return c.GetObjectPageWithContext(context.Background(), obj, pageID, maxResults, res)
+}
+
+func (c *fakeConnector) GetObjectPageWithContext(ctx context.Context, obj IBObject, pageID string, maxResults int, res interface{}) (string, error) {
+	return "", c.GetObjectWithContext(ctx, obj, "", res)
+}
+
+var _ IBConnector = &fakeConnector{}
+
 // var _ = Describe("Object Manager", func() {
 
 // 	Describe("Create Network View", func() {
@@ -1829,3 +1863,140 @@ func (c *fakeConnector) UpdateObject(obj IBObject, ref string) (string, error) {
 // 		})
 // 	})
 // })
+
+func TestBuildNetworkFromRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		cidr    string
+		netview string
+	}{
+		{"network/ZG5zLm5ldHdvcmskODkuMC4wLjAvMjQvMjU:89.0.0.0/24/global_view", "89.0.0.0/24", "global_view"},
+		{"ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi82NC8w:2001:db8::/64/default", "2001:db8::/64", "default"},
+		{"networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEwLjAuMC4wLzgvMA:10.0.0.0/8/default", "", ""},
+	}
+	for _, tt := range tests {
+		network := BuildNetworkFromRef(tt.ref)
+		if tt.cidr == "" {
+			if network != nil {
+				t.Errorf("%s: unexpected network %+v", tt.ref, network)
+			}
+			continue
+		}
+		if network == nil || network.Cidr != tt.cidr || network.NetviewName != tt.netview {
+			t.Errorf("%s: unexpected network %+v", tt.ref, network)
+		}
+	}
+
+	ipv6Network := BuildIPv6NetworkFromRef("ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi82NC8w:2001:db8::/64/default")
+	if ipv6Network == nil || ipv6Network.Cidr != "2001:db8::/64" || ipv6Network.NetviewName != "default" {
+		t.Errorf("unexpected IPv6 network %+v", ipv6Network)
+	}
+	if ipv6Network := BuildIPv6NetworkFromRef("network/ZG5zLm5ldHdvcmskODkuMC4wLjAvMjQvMjU:89.0.0.0/24/default"); ipv6Network != nil {
+		t.Errorf("unexpected IPv6 network %+v", ipv6Network)
+	}
+}
+
+func TestGetIPAddressFromRef(t *testing.T) {
+	tests := map[string]string{
+		"fixedaddress/ZG5zLmJpbmRfY25h:12.0.10.1/external":             "12.0.10.1",
+		"ipv6fixedaddress/ZG5zLmJpbmRfY25h:2001:db8::1/default":        "2001:db8::1",
+		"ipv6fixedaddress/ZG5zLmJpbmRfY25h:2001:db8::ffff:a00:1/other": "2001:db8::ffff:a00:1",
+		"record:a/ZG5zLmJpbmRfY25h:a.example.com/default":              "",
+	}
+	for ref, expected := range tests {
+		if ip := GetIPAddressFromRef(ref); ip != expected {
+			t.Errorf("%s: got %q, expected %q", ref, ip, expected)
+		}
+	}
+}
+
+func TestRangeOfNetwork(t *testing.T) {
+	for _, tc := range []struct {
+		cidr       string
+		percent    int
+		start, end string
+	}{
+		{"10.0.0.0/24", 50, "10.0.0.128", "10.0.0.254"},
+		{"10.0.0.0/24", 100, "10.0.0.1", "10.0.0.254"},
+		{"10.0.0.0/16", 25, "10.0.192.0", "10.0.255.254"},
+		{"10.0.0.0/30", 50, "10.0.0.2", "10.0.0.2"},
+	} {
+		start, end, err := rangeOfNetwork(tc.cidr, tc.percent)
+		if err != nil || start != tc.start || end != tc.end {
+			t.Errorf("%s %d%%: got %s-%s, error %v, expected %s-%s", tc.cidr, tc.percent, start, end, err, tc.start, tc.end)
+		}
+	}
+
+	for _, tc := range []struct {
+		cidr    string
+		percent int
+	}{
+		{"10.0.0.0/24", 0},
+		{"10.0.0.0/24", 101},
+		{"10.0.0.0/31", 100},
+		{"10.0.0.0/29", 10},
+		{"2001:db8::/64", 50},
+	} {
+		if _, _, err := rangeOfNetwork(tc.cidr, tc.percent); err == nil {
+			t.Errorf("%s %d%%: expected an error", tc.cidr, tc.percent)
+		}
+	}
+}
+
+func TestGetUnreservedLeasesPages(t *testing.T) {
+	// the second page of each object type is needed to tell the leases apart
+	pages := map[string][2]string{
+		"lease": {`[{"_ref": "lease/a:10.0.0.5/default", "address": "10.0.0.5"}]`,
+			`[{"_ref": "lease/b:10.0.0.6/default", "address": "10.0.0.6"},
+			{"_ref": "lease/c:10.0.0.7/default", "address": "10.0.0.7"}]`},
+		"fixedaddress":         {`[]`, `[{"_ref": "fixedaddress/a:10.0.0.5/default", "ipv4addr": "10.0.0.5"}]`},
+		"record:host_ipv4addr": {`[]`, `[{"_ref": "record:host_ipv4addr/a:10.0.0.6/default", "ipv4addr": "10.0.0.6"}]`},
+	}
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		objType := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		q := r.URL.Query()
+		switch q.Get("_page_id") {
+		case "":
+			body, _ := io.ReadAll(r.Body)
+			if q.Get("_paging") != "1" || !strings.Contains(string(body), `"network":"10.0.0.0/24"`) {
+				t.Errorf("unexpected %s search: %s %s", objType, r.URL.RawQuery, body)
+			}
+			_, _ = w.Write([]byte(`{"result": ` + pages[objType][0] + `, "next_page_id": "` + objType + `"}`))
+		case objType:
+			_, _ = w.Write([]byte(`{"result": ` + pages[objType][1] + `}`))
+		default:
+			t.Errorf("unexpected %s page id %q", objType, q.Get("_page_id"))
+		}
+	})
+
+	leases, err := NewObjectManager(conn, "cmp", "tenant").GetUnreservedLeases("default", "10.0.0.0/24")
+	if err != nil || len(leases) != 1 || leases[0].Address != "10.0.0.7" {
+		t.Errorf("unexpected leases %+v, error %v", leases, err)
+	}
+}
+
+func TestGetIPv4AddressesPages(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch q.Get("_page_id") {
+		case "":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.HasSuffix(r.URL.Path, "/ipv4address") || q.Get("_paging") != "1" ||
+				q.Get("_return_as_object") != "1" || !strings.Contains(q.Get("_return_fields"), "status") ||
+				string(body) != `{"network":"10.0.0.0/16","network_view":"default","status":"USED"}` {
+				t.Errorf("unexpected search: %s %s %s", r.URL.Path, r.URL.RawQuery, body)
+			}
+			_, _ = w.Write([]byte(`{"result": [{"_ref": "ipv4address/a:10.0.0.1", "ip_address": "10.0.0.1", "status": "USED"}],
+				"next_page_id": "p2"}`))
+		case "p2":
+			_, _ = w.Write([]byte(`{"result": [{"_ref": "ipv4address/b:10.0.255.1", "ip_address": "10.0.255.1", "status": "USED"}]}`))
+		default:
+			t.Errorf("unexpected page id %q", q.Get("_page_id"))
+		}
+	})
+
+	addrs, err := NewObjectManager(conn, "cmp", "tenant").GetIPv4Addresses("default", "10.0.0.0/16", IPAddressStatusUsed)
+	if err != nil || len(addrs) != 2 || addrs[1].IPAddress != "10.0.255.1" {
+		t.Errorf("unexpected addresses %+v, error %v", addrs, err)
+	}
+}
@@ -24,6 +24,7 @@ type IBBase struct {
 	objectType   string
 	returnFields []string
 	eaSearch     EASearch
+	query        *Query
 }
 
 // IBObject ???
@@ -31,6 +32,7 @@ type IBObject interface {
 	ObjectType() string
 	ReturnFields() []string
 	EaSearch() EASearch
+	Query() *Query
 	//SetReturnFields([]string)
 }
 
@@ -49,6 +51,16 @@ func (obj *IBBase) EaSearch() EASearch {
 	return obj.eaSearch
 }
 
+// Query returns the search arguments added to GET requests for the object
+func (obj *IBBase) Query() *Query {
+	return obj.query
+}
+
+// SetQuery sets the search arguments added to GET requests for the object
+func (obj *IBBase) SetQuery(q *Query) {
+	obj.query = q
+}
+
 // NetworkView ???
 type NetworkView struct {
 	IBBase `json:"-"`
@@ -101,17 +113,18 @@ type SubElementsStatus struct {
 // Network ???
 type Network struct {
 	IBBase
-	Ref         string `json:"_ref,omitempty"`
-	NetviewName string `json:"network_view,omitempty"`
-	Cidr        string `json:"network,omitempty"`
-	Ea          EA     `json:"extattrs,omitempty"`
+	Ref         string       `json:"_ref,omitempty"`
+	NetviewName string       `json:"network_view,omitempty"`
+	Cidr        string       `json:"network,omitempty"`
+	Options     []DhcpOption `json:"options,omitempty"`
+	Ea          EA           `json:"extattrs,omitempty"`
 }
 
 // NewNetwork ???
 func NewNetwork(nw Network) *Network {
 	res := nw
 	res.objectType = "network"
-	res.returnFields = []string{"extattrs", "network", "network_view"}
+	res.returnFields = []string{"extattrs", "network", "network_view", "options"}
 
 	return &res
 }
@@ -324,27 +337,304 @@ func NewNetworkContainer(nc NetworkContainer) *NetworkContainer {
 
 // FixedAddress ???
 type FixedAddress struct {
+	IBBase      `json:"-"`
+	Ref         string       `json:"_ref,omitempty"`
+	NetviewName string       `json:"network_view,omitempty"`
+	Cidr        string       `json:"network,omitempty"`
+	IPAddress   string       `json:"ipv4addr,omitempty"`
+	Mac         string       `json:"mac,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	MatchClient string       `json:"match_client,omitempty"`
+	Options     []DhcpOption `json:"options,omitempty"`
+	Ea          EA           `json:"extattrs,omitempty"`
+}
+
+// Server association types of DHCP ranges
+const (
+	// ServerAssociationNone leaves the range unserved
+	ServerAssociationNone = "NONE"
+	// ServerAssociationMember serves the range from a single Grid member
+	ServerAssociationMember = "MEMBER"
+	// ServerAssociationFailover serves the range from a failover association
+	ServerAssociationFailover = "FAILOVER"
+)
+
+// DhcpMember is a Grid member serving DHCP, identified by its name
+type DhcpMember struct {
+	Name     string `json:"name,omitempty"`
+	Ipv4Addr string `json:"ipv4addr,omitempty"`
+	Ipv6Addr string `json:"ipv6addr,omitempty"`
+}
+
+// ExclusionRange is a part of a DHCP range whose addresses are not leased
+type ExclusionRange struct {
+	StartAddress string `json:"start_address"`
+	EndAddress   string `json:"end_address"`
+	Comment      string `json:"comment,omitempty"`
+}
+
+// Range is a DHCP range, a pool of addresses of a network leased to clients
+type Range struct {
+	IBBase                `json:"-"`
+	Ref                   string           `json:"_ref,omitempty"`
+	NetviewName           string           `json:"network_view,omitempty"`
+	Cidr                  string           `json:"network,omitempty"`
+	StartAddr             string           `json:"start_addr,omitempty"`
+	EndAddr               string           `json:"end_addr,omitempty"`
+	Name                  string           `json:"name,omitempty"`
+	Comment               string           `json:"comment,omitempty"`
+	ServerAssociationType string           `json:"server_association_type,omitempty"`
+	Member                *DhcpMember      `json:"member,omitempty"`
+	FailoverAssociation   string           `json:"failover_association,omitempty"`
+	Exclude               []ExclusionRange `json:"exclude,omitempty"`
+	Disable               *bool            `json:"disable,omitempty"`
+	Options               []DhcpOption     `json:"options,omitempty"`
+	Ea                    EA               `json:"extattrs,omitempty"`
+}
+
+// NewRange ???
+func NewRange(r Range) *Range {
+	res := r
+	res.objectType = "range"
+	res.returnFields = []string{"comment", "disable", "end_addr", "exclude", "extattrs", "failover_association",
+		"member", "name", "network", "network_view", "options", "server_association_type", "start_addr"}
+
+	return &res
+}
+
+// Binding states of DHCP leases
+const (
+	LeaseStateActive    = "ACTIVE"
+	LeaseStateFree      = "FREE"
+	LeaseStateExpired   = "EXPIRED"
+	LeaseStateReleased  = "RELEASED"
+	LeaseStateAbandoned = "ABANDONED"
+	LeaseStateBackup    = "BACKUP"
+	LeaseStateStatic    = "STATIC"
+)
+
+// Lease is a DHCP lease. Leases are read-only, Starts and Ends are Unix
+// times.
+type Lease struct {
+	IBBase         `json:"-"`
+	Ref            string `json:"_ref,omitempty"`
+	Address        string `json:"address,omitempty"`
+	Hardware       string `json:"hardware,omitempty"`
+	ClientHostname string `json:"client_hostname,omitempty"`
+	Network        string `json:"network,omitempty"`
+	NetworkView    string `json:"network_view,omitempty"`
+	BindingState   string `json:"binding_state,omitempty"`
+	Protocol       string `json:"protocol,omitempty"`
+	Starts         int64  `json:"starts,omitempty"`
+	Ends           int64  `json:"ends,omitempty"`
+	ServedBy       string `json:"served_by,omitempty"`
+}
+
+// NewLease ???
+func NewLease(lease Lease) *Lease {
+	res := lease
+	res.objectType = "lease"
+	res.returnFields = []string{"address", "binding_state", "client_hostname", "ends", "hardware", "network",
+		"network_view", "protocol", "served_by", "starts"}
+
+	return &res
+}
+
+// Statuses of IP addresses
+const (
+	IPAddressStatusUsed   = "USED"
+	IPAddressStatusUnused = "UNUSED"
+)
+
+// Types of the usage of IP addresses, the types of the objects using them
+const (
+	IPAddressTypeFixedAddress = "FIXEDADDRESS"
+	IPAddressTypeHost         = "HOST"
+	IPAddressTypeLease        = "LEASE"
+	IPAddressTypeA            = "A"
+	IPAddressTypeAAAA         = "AAAA"
+	IPAddressTypePTR          = "PTR"
+	IPAddressTypeNetwork      = "NETWORK"
+	IPAddressTypeBroadcast    = "BROADCAST"
+)
+
+// IPv4Address is the status of an IPv4 address of a network: whether it is
+// used, by which objects and whether their uses conflict. IPv4 addresses
+// are read-only and must be searched by Network or IPAddress.
+type IPv4Address struct {
+	IBBase        `json:"-"`
+	Ref           string   `json:"_ref,omitempty"`
+	IPAddress     string   `json:"ip_address,omitempty"`
+	Network       string   `json:"network,omitempty"`
+	NetworkView   string   `json:"network_view,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Types         []string `json:"types,omitempty"`
+	Objects       []string `json:"objects,omitempty"`
+	Names         []string `json:"names,omitempty"`
+	Usage         []string `json:"usage,omitempty"`
+	MacAddress    string   `json:"mac_address,omitempty"`
+	IsConflict    bool     `json:"is_conflict,omitempty"`
+	ConflictTypes []string `json:"conflict_types,omitempty"`
+	LeaseState    string   `json:"lease_state,omitempty"`
+}
+
+// NewIPv4Address ???
+func NewIPv4Address(addr IPv4Address) *IPv4Address {
+	res := addr
+	res.objectType = "ipv4address"
+	res.returnFields = []string{"conflict_types", "ip_address", "is_conflict", "lease_state", "mac_address",
+		"names", "network", "network_view", "objects", "status", "types", "usage"}
+
+	return &res
+}
+
+// IPv6Address is the status of an IPv6 address of a network, see
+// IPv4Address
+type IPv6Address struct {
+	IBBase        `json:"-"`
+	Ref           string   `json:"_ref,omitempty"`
+	IPAddress     string   `json:"ip_address,omitempty"`
+	Network       string   `json:"network,omitempty"`
+	NetworkView   string   `json:"network_view,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Types         []string `json:"types,omitempty"`
+	Objects       []string `json:"objects,omitempty"`
+	Names         []string `json:"names,omitempty"`
+	Usage         []string `json:"usage,omitempty"`
+	Duid          string   `json:"duid,omitempty"`
+	IsConflict    bool     `json:"is_conflict,omitempty"`
+	ConflictTypes []string `json:"conflict_types,omitempty"`
+}
+
+// NewIPv6Address ???
+func NewIPv6Address(addr IPv6Address) *IPv6Address {
+	res := addr
+	res.objectType = "ipv6address"
+	res.returnFields = []string{"conflict_types", "duid", "ip_address", "is_conflict", "names", "network",
+		"network_view", "objects", "status", "types", "usage"}
+
+	return &res
+}
+
+// NextAvailableIPArgs are the arguments of the next_available_ip function
+// of networks and ranges: the number of addresses to return and addresses
+// not to return
+type NextAvailableIPArgs struct {
+	IBBase  `json:"-"`
+	Num     int      `json:"num"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// NewNextAvailableIPArgs ???
+func NewNextAvailableIPArgs(args NextAvailableIPArgs) *NextAvailableIPArgs {
+	res := args
+	res.objectType = "next_available_ip"
+
+	return &res
+}
+
+// NextAvailableNetworkArgs are the arguments of the next_available_network
+// function of network containers and networks: the prefix length and
+// number of the networks to return and networks not to return
+type NextAvailableNetworkArgs struct {
+	IBBase  `json:"-"`
+	Cidr    uint     `json:"cidr"`
+	Num     int      `json:"num"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// NewNextAvailableNetworkArgs ???
+func NewNextAvailableNetworkArgs(args NextAvailableNetworkArgs) *NextAvailableNetworkArgs {
+	res := args
+	res.objectType = "next_available_network"
+
+	return &res
+}
+
+// IPv6Network ???
+type IPv6Network struct {
+	IBBase      `json:"-"`
+	Ref         string `json:"_ref,omitempty"`
+	NetviewName string `json:"network_view,omitempty"`
+	Cidr        string `json:"network,omitempty"`
+	Ea          EA     `json:"extattrs,omitempty"`
+}
+
+// NewIPv6Network ???
+func NewIPv6Network(nw IPv6Network) *IPv6Network {
+	res := nw
+	res.objectType = "ipv6network"
+	res.returnFields = []string{"extattrs", "network", "network_view"}
+
+	return &res
+}
+
+// IPv6NetworkContainer ???
+type IPv6NetworkContainer struct {
 	IBBase      `json:"-"`
 	Ref         string `json:"_ref,omitempty"`
 	NetviewName string `json:"network_view,omitempty"`
 	Cidr        string `json:"network,omitempty"`
-	IPAddress   string `json:"ipv4addr,omitempty"`
-	Mac         string `json:"mac,omitempty"`
+	Ea          EA     `json:"extattrs,omitempty"`
+}
+
+// NewIPv6NetworkContainer ???
+func NewIPv6NetworkContainer(nc IPv6NetworkContainer) *IPv6NetworkContainer {
+	res := nc
+	res.objectType = "ipv6networkcontainer"
+	res.returnFields = []string{"extattrs", "network", "network_view"}
+
+	return &res
+}
+
+// IPv6FixedAddress is a DHCPv6 fixed address, identified by the DUID of the
+// client rather than a MAC address
+type IPv6FixedAddress struct {
+	IBBase      `json:"-"`
+	Ref         string `json:"_ref,omitempty"`
+	NetviewName string `json:"network_view,omitempty"`
+	Cidr        string `json:"network,omitempty"`
+	IPAddress   string `json:"ipv6addr,omitempty"`
+	Duid        string `json:"duid,omitempty"`
 	Name        string `json:"name,omitempty"`
-	MatchClient string `json:"match_client,omitempty"`
 	Ea          EA     `json:"extattrs,omitempty"`
 }
 
+// NewIPv6FixedAddress ???
+func NewIPv6FixedAddress(fixedAddr IPv6FixedAddress) *IPv6FixedAddress {
+	res := fixedAddr
+	res.objectType = "ipv6fixedaddress"
+	res.returnFields = []string{"duid", "extattrs", "ipv6addr", "name", "network", "network_view"}
+
+	return &res
+}
+
 // QueryParams is a general struct to add query params used in makeRequest
 type QueryParams struct {
 	forceProxy bool
+
+	// paging requests the results as an object holding a page of at most
+	// maxResults objects; pageID selects a page after the first one
+	paging     bool
+	maxResults int
+	pageID     string
+
+	// query holds search arguments beyond exact field matches
+	query *Query
+
+	// authenticate sends the credentials with AuthSession, to log in
+	authenticate bool
+
+	// function is the WAPI function called on the referenced object, with
+	// the object of the request as its arguments
+	function string
 }
 
 // NewFixedAddress ???
 func NewFixedAddress(fixedAddr FixedAddress) *FixedAddress {
 	res := fixedAddr
 	res.objectType = "fixedaddress"
-	res.returnFields = []string{"extattrs", "ipv4addr", "mac", "name", "network", "network_view"}
+	res.returnFields = []string{"extattrs", "ipv4addr", "mac", "name", "network", "network_view", "options"}
 
 	return &res
 }
@@ -394,6 +684,9 @@ type RecordA struct {
 	Name     string `json:"name,omitempty"`
 	View     string `json:"view,omitempty"`
 	Zone     string `json:"zone,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	TTL      uint32 `json:"ttl,omitempty"`
+	UseTTL   *bool  `json:"use_ttl,omitempty"`
 	Ea       EA     `json:"extattrs,omitempty"`
 }
 
@@ -401,7 +694,129 @@ type RecordA struct {
 func NewRecordA(ra RecordA) *RecordA {
 	res := ra
 	res.objectType = "record:a"
-	res.returnFields = []string{"extattrs", "ipv4addr", "name", "view", "zone"}
+	res.returnFields = []string{"comment", "extattrs", "ipv4addr", "name", "ttl", "use_ttl", "view", "zone"}
+
+	return &res
+}
+
+// RecordAAAA is an IPv6 address record
+type RecordAAAA struct {
+	IBBase   `json:"-"`
+	Ref      string `json:"_ref,omitempty"`
+	Ipv6Addr string `json:"ipv6addr,omitempty"`
+	Name     string `json:"name,omitempty"`
+	View     string `json:"view,omitempty"`
+	Zone     string `json:"zone,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	TTL      uint32 `json:"ttl,omitempty"`
+	UseTTL   *bool  `json:"use_ttl,omitempty"`
+	Ea       EA     `json:"extattrs,omitempty"`
+}
+
+// NewRecordAAAA ???
+func NewRecordAAAA(rec RecordAAAA) *RecordAAAA {
+	res := rec
+	res.objectType = "record:aaaa"
+	res.returnFields = []string{"comment", "extattrs", "ipv6addr", "name", "ttl", "use_ttl", "view", "zone"}
+
+	return &res
+}
+
+// RecordMX is a mail exchanger record
+type RecordMX struct {
+	IBBase        `json:"-"`
+	Ref           string  `json:"_ref,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	MailExchanger string  `json:"mail_exchanger,omitempty"`
+	Preference    *uint32 `json:"preference,omitempty"`
+	TTL           uint32  `json:"ttl,omitempty"`
+	UseTTL        *bool   `json:"use_ttl,omitempty"`
+	View          string  `json:"view,omitempty"`
+	Zone          string  `json:"zone,omitempty"`
+	Ea            EA      `json:"extattrs,omitempty"`
+}
+
+// NewRecordMX ???
+func NewRecordMX(rec RecordMX) *RecordMX {
+	res := rec
+	res.objectType = "record:mx"
+	res.returnFields = []string{"extattrs", "mail_exchanger", "name", "preference", "ttl", "use_ttl", "view", "zone"}
+
+	return &res
+}
+
+// RecordSRV is a service record
+type RecordSRV struct {
+	IBBase   `json:"-"`
+	Ref      string  `json:"_ref,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	Target   string  `json:"target,omitempty"`
+	Priority *uint32 `json:"priority,omitempty"`
+	Weight   *uint32 `json:"weight,omitempty"`
+	Port     *uint32 `json:"port,omitempty"`
+	TTL      uint32  `json:"ttl,omitempty"`
+	UseTTL   *bool   `json:"use_ttl,omitempty"`
+	View     string  `json:"view,omitempty"`
+	Zone     string  `json:"zone,omitempty"`
+	Ea       EA      `json:"extattrs,omitempty"`
+}
+
+// NewRecordSRV ???
+func NewRecordSRV(rec RecordSRV) *RecordSRV {
+	res := rec
+	res.objectType = "record:srv"
+	res.returnFields = []string{"extattrs", "name", "port", "priority", "target", "ttl", "use_ttl", "view", "weight", "zone"}
+
+	return &res
+}
+
+// NSRecordAddress is an address of the name server of an NS record
+type NSRecordAddress struct {
+	Address       string `json:"address,omitempty"`
+	AutoCreatePtr bool   `json:"auto_create_ptr"`
+}
+
+// RecordNS is a name server record. WAPI supports neither TTLs nor
+// extensible attributes on NS records.
+type RecordNS struct {
+	IBBase     `json:"-"`
+	Ref        string            `json:"_ref,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Nameserver string            `json:"nameserver,omitempty"`
+	Addresses  []NSRecordAddress `json:"addresses,omitempty"`
+	View       string            `json:"view,omitempty"`
+	Zone       string            `json:"zone,omitempty"`
+}
+
+// NewRecordNS ???
+func NewRecordNS(rec RecordNS) *RecordNS {
+	res := rec
+	res.objectType = "record:ns"
+	res.returnFields = []string{"addresses", "name", "nameserver", "view", "zone"}
+
+	return &res
+}
+
+// RecordCAA is a certification authority authorization record
+type RecordCAA struct {
+	IBBase  `json:"-"`
+	Ref     string  `json:"_ref,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	CAFlag  *uint32 `json:"ca_flag,omitempty"`
+	CATag   string  `json:"ca_tag,omitempty"`
+	CAValue string  `json:"ca_value,omitempty"`
+	TTL     uint32  `json:"ttl,omitempty"`
+	UseTTL  *bool   `json:"use_ttl,omitempty"`
+	View    string  `json:"view,omitempty"`
+	Zone    string  `json:"zone,omitempty"`
+	Ea      EA      `json:"extattrs,omitempty"`
+}
+
+// NewRecordCAA ???
+func NewRecordCAA(rec RecordCAA) *RecordCAA {
+	res := rec
+	res.objectType = "record:caa"
+	res.returnFields = []string{"ca_flag", "ca_tag", "ca_value", "extattrs", "name", "ttl", "use_ttl", "view", "zone"}
 
 	return &res
 }
@@ -415,6 +830,9 @@ type RecordPTR struct {
 	PtrdName string `json:"ptrdname,omitempty"`
 	View     string `json:"view,omitempty"`
 	Zone     string `json:"zone,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	TTL      uint32 `json:"ttl,omitempty"`
+	UseTTL   *bool  `json:"use_ttl,omitempty"`
 	Ea       EA     `json:"extattrs,omitempty"`
 }
 
@@ -422,7 +840,7 @@ type RecordPTR struct {
 func NewRecordPTR(rptr RecordPTR) *RecordPTR {
 	res := rptr
 	res.objectType = "record:ptr"
-	res.returnFields = []string{"extattrs", "ipv4addr", "ptrdname", "view", "zone"}
+	res.returnFields = []string{"comment", "extattrs", "ipv4addr", "name", "ptrdname", "ttl", "use_ttl", "view", "zone"}
 
 	return &res
 }
@@ -435,6 +853,9 @@ type RecordCNAME struct {
 	Name      string `json:"name,omitempty"`
 	View      string `json:"view,omitempty"`
 	Zone      string `json:"zone,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	TTL       uint32 `json:"ttl,omitempty"`
+	UseTTL    *bool  `json:"use_ttl,omitempty"`
 	Ea        EA     `json:"extattrs,omitempty"`
 }
 
@@ -442,25 +863,102 @@ type RecordCNAME struct {
 func NewRecordCNAME(rc RecordCNAME) *RecordCNAME {
 	res := rc
 	res.objectType = "record:cname"
-	res.returnFields = []string{"extattrs", "canonical", "name", "view", "zone"}
+	res.returnFields = []string{"canonical", "comment", "extattrs", "name", "ttl", "use_ttl", "view", "zone"}
+
+	return &res
+}
+
+// DhcpOption is a DHCP option, identified by its name or number, e.g.
+// DhcpOption{Name: "routers", Value: "10.0.0.1"}
+type DhcpOption struct {
+	Name        string `json:"name,omitempty"`
+	Num         uint32 `json:"num,omitempty"`
+	Value       string `json:"value"`
+	VendorClass string `json:"vendor_class,omitempty"`
+	UseOption   *bool  `json:"use_option,omitempty"`
+}
+
+// DhcpOptionSpace is a space of custom DHCP options, e.g. the vendor
+// options of a class of devices
+type DhcpOptionSpace struct {
+	IBBase            `json:"-"`
+	Ref               string   `json:"_ref,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	Comment           string   `json:"comment,omitempty"`
+	OptionDefinitions []string `json:"option_definitions,omitempty"`
+}
+
+// NewDhcpOptionSpace ???
+func NewDhcpOptionSpace(space DhcpOptionSpace) *DhcpOptionSpace {
+	res := space
+	res.objectType = "dhcpoptionspace"
+	res.returnFields = []string{"comment", "name", "option_definitions"}
+
+	return &res
+}
+
+// DhcpOptionDefinition defines a custom DHCP option of an option space.
+// Type is the WAPI type of its values, e.g. "string", "ip-address" or
+// "16-bit unsigned integer".
+type DhcpOptionDefinition struct {
+	IBBase `json:"-"`
+	Ref    string `json:"_ref,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Code   uint32 `json:"code,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Space  string `json:"space,omitempty"`
+}
+
+// NewDhcpOptionDefinition ???
+func NewDhcpOptionDefinition(def DhcpOptionDefinition) *DhcpOptionDefinition {
+	res := def
+	res.objectType = "dhcpoptiondefinition"
+	res.returnFields = []string{"code", "name", "space", "type"}
 
 	return &res
 }
 
 // HostRecordIpv4Addr ???
 type HostRecordIpv4Addr struct {
-	IBBase   `json:"-"`
-	Ipv4Addr string `json:"ipv4addr,omitempty"`
-	Ref      string `json:"_ref,omitempty"`
-	Mac      string `json:"mac,omitempty"`
-	View     string `json:"view,omitempty"`
-	Cidr     string `json:"network,omitempty"`
+	IBBase           `json:"-"`
+	Ipv4Addr         string       `json:"ipv4addr,omitempty"`
+	Ref              string       `json:"_ref,omitempty"`
+	Mac              string       `json:"mac,omitempty"`
+	View             string       `json:"view,omitempty"`
+	Cidr             string       `json:"network,omitempty"`
+	NetworkView      string       `json:"network_view,omitempty"`
+	Host             string       `json:"host,omitempty"`
+	ConfigureForDHCP *bool        `json:"configure_for_dhcp,omitempty"`
+	Options          []DhcpOption `json:"options,omitempty"`
 }
 
 // NewHostRecordIpv4Addr ???
 func NewHostRecordIpv4Addr(hostAddr HostRecordIpv4Addr) *HostRecordIpv4Addr {
 	res := hostAddr
 	res.objectType = "record:host_ipv4addr"
+	res.returnFields = []string{"configure_for_dhcp", "host", "ipv4addr", "mac", "network", "options"}
+	return &res
+}
+
+// HostRecordIpv6Addr is an IPv6 address of a host record
+type HostRecordIpv6Addr struct {
+	IBBase           `json:"-"`
+	Ipv6Addr         string       `json:"ipv6addr,omitempty"`
+	Ref              string       `json:"_ref,omitempty"`
+	Duid             string       `json:"duid,omitempty"`
+	View             string       `json:"view,omitempty"`
+	Cidr             string       `json:"network,omitempty"`
+	NetworkView      string       `json:"network_view,omitempty"`
+	Host             string       `json:"host,omitempty"`
+	ConfigureForDHCP *bool        `json:"configure_for_dhcp,omitempty"`
+	Options          []DhcpOption `json:"options,omitempty"`
+}
+
+// NewHostRecordIpv6Addr ???
+func NewHostRecordIpv6Addr(hostAddr HostRecordIpv6Addr) *HostRecordIpv6Addr {
+	res := hostAddr
+	res.objectType = "record:host_ipv6addr"
+	res.returnFields = []string{"configure_for_dhcp", "duid", "host", "ipv6addr", "network", "options"}
 	return &res
 }
 
@@ -470,19 +968,30 @@ type HostRecord struct {
 	Ref         string               `json:"_ref,omitempty"`
 	Ipv4Addr    string               `json:"ipv4addr,omitempty"`
 	Ipv4Addrs   []HostRecordIpv4Addr `json:"ipv4addrs,omitempty"`
+	Ipv6Addrs   []HostRecordIpv6Addr `json:"ipv6addrs,omitempty"`
 	Name        string               `json:"name,omitempty"`
+	Aliases     []string             `json:"aliases,omitempty"`
 	View        string               `json:"view,omitempty"`
 	Zone        string               `json:"zone,omitempty"`
 	EnableDNS   *bool                `json:"configure_for_dns,omitempty"`
 	NetworkView string               `json:"network_view,omitempty"`
 	Ea          EA                   `json:"extattrs,omitempty"`
+
+	// The fields below add elements to or remove them from the lists of an
+	// existing host record, they are only used in updates
+	Ipv4AddrsAdd    []HostRecordIpv4Addr `json:"ipv4addrs+,omitempty"`
+	Ipv4AddrsRemove []HostRecordIpv4Addr `json:"ipv4addrs-,omitempty"`
+	Ipv6AddrsAdd    []HostRecordIpv6Addr `json:"ipv6addrs+,omitempty"`
+	Ipv6AddrsRemove []HostRecordIpv6Addr `json:"ipv6addrs-,omitempty"`
+	AliasesAdd      []string             `json:"aliases+,omitempty"`
+	AliasesRemove   []string             `json:"aliases-,omitempty"`
 }
 
 // NewHostRecord ???
 func NewHostRecord(rh HostRecord) *HostRecord {
 	res := rh
 	res.objectType = "record:host"
-	res.returnFields = []string{"extattrs", "ipv4addrs", "name", "view", "zone"}
+	res.returnFields = []string{"aliases", "extattrs", "ipv4addrs", "ipv6addrs", "name", "view", "zone"}
 
 	return &res
 }
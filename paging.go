@@ -0,0 +1,108 @@
+package ibclient
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// DefaultPageSize is the number of objects requested per page when no
+// positive page size is given, matching the WAPI default result limit
+const DefaultPageSize = 1000
+
+// PageIterator walks through the pages of a WAPI query one at a time so
+// that large result sets never have to be held in memory at once.
+//
+//	it := NewPageIterator(conn, NewFixedAddress(FixedAddress{NetviewName: "default"}), 500)
+//	var page []FixedAddress
+//	for it.Next(&page) {
+//		// process page
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type PageIterator struct {
+	conn     IBConnector
+	obj      IBObject
+	pageSize int
+
+	pageID string
+	done   bool
+	err    error
+}
+
+// NewPageIterator returns a PageIterator over the objects matching obj,
+// fetching pageSize objects per request
+func NewPageIterator(conn IBConnector, obj IBObject, pageSize int) *PageIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return &PageIterator{
+		conn:     conn,
+		obj:      obj,
+		pageSize: pageSize,
+	}
+}
+
+// Next fetches the next page into res, which must be a pointer to a slice.
+// It returns false when there are no more pages or an error occurred.
+func (it *PageIterator) Next(res interface{}) bool {
+	return it.NextWithContext(context.Background(), res)
+}
+
+// NextWithContext is like Next but the request is bound to ctx.
+func (it *PageIterator) NextWithContext(ctx context.Context, res interface{}) bool {
+	if it.done {
+		return false
+	}
+
+	rv := reflect.ValueOf(res)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		it.err = fmt.Errorf("page result must be a pointer to a slice, got %T", res)
+		it.done = true
+		return false
+	}
+	// don't leak the previous page into an empty one
+	rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+
+	nextPageID, err := it.conn.GetObjectPageWithContext(ctx, it.obj, it.pageID, it.pageSize, res)
+	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+
+	it.pageID = nextPageID
+	it.done = nextPageID == ""
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// GetAllObjects fetches every object matching obj, pageSize objects at a
+// time, and appends them to res, which must be a pointer to a slice
+func GetAllObjects(conn IBConnector, obj IBObject, pageSize int, res interface{}) error {
+	return GetAllObjectsWithContext(context.Background(), conn, obj, pageSize, res)
+}
+
+// GetAllObjectsWithContext is like GetAllObjects but the requests are bound
+// to ctx.
+func GetAllObjectsWithContext(ctx context.Context, conn IBConnector, obj IBObject, pageSize int, res interface{}) error {
+	rv := reflect.ValueOf(res)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("result must be a pointer to a slice, got %T", res)
+	}
+	all := rv.Elem()
+
+	it := NewPageIterator(conn, obj, pageSize)
+	page := reflect.New(all.Type())
+	for it.NextWithContext(ctx, page.Interface()) {
+		all.Set(reflect.AppendSlice(all, page.Elem()))
+	}
+
+	return it.Err()
+}
@@ -0,0 +1,53 @@
+package ibclient
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetAllObjects(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch q.Get("_page_id") {
+		case "":
+			if q.Get("_paging") != "1" || q.Get("_return_as_object") != "1" || q.Get("_max_results") != "2" {
+				t.Errorf("unexpected first page query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"result": [{"_ref": "network/a:10.0.0.0/24/default", "network": "10.0.0.0/24"},
+				{"_ref": "network/b:10.0.1.0/24/default", "network": "10.0.1.0/24"}], "next_page_id": "p2"}`))
+		case "p2":
+			if q.Get("_paging") != "" {
+				t.Errorf("unexpected next page query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"result": [{"_ref": "network/c:10.0.2.0/24/default", "network": "10.0.2.0/24"}]}`))
+		default:
+			t.Errorf("unexpected page id %q", q.Get("_page_id"))
+		}
+	})
+
+	var res []Network
+	err := GetAllObjects(conn, NewNetwork(Network{NetviewName: "default"}), 2, &res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 3 || res[2].Cidr != "10.0.2.0/24" {
+		t.Errorf("unexpected result: %+v", res)
+	}
+}
+
+func TestPageIteratorStopsOnError(t *testing.T) {
+	conn := newTestConnector(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"Error": "AdmConProtoError: Result set too large (> 1000)", "code": "Client.Ibap.Proto", "text": "Result set too large (> 1000)"}`))
+	})
+	conn.TransportConfig.ProxySearch = ProxySearchDisabled
+
+	it := NewPageIterator(conn, NewFixedAddress(FixedAddress{}), 0)
+	var page []FixedAddress
+	if it.Next(&page) {
+		t.Fatal("expected no page")
+	}
+	if it.Err() == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,152 @@
+package ibclient
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// SearchModifier is a WAPI search modifier appended to a field name, e.g.
+// the "~" of name~=^web
+type SearchModifier string
+
+const (
+	// SearchNegate matches objects whose field does not match the value
+	SearchNegate SearchModifier = "!"
+	// SearchCaseInsensitive matches the value ignoring case
+	SearchCaseInsensitive SearchModifier = ":"
+	// SearchRegex matches the value as a regular expression
+	SearchRegex SearchModifier = "~"
+	// SearchLessOrEqual matches values lower than or equal to the value
+	SearchLessOrEqual SearchModifier = "<"
+	// SearchGreaterOrEqual matches values greater than or equal to the value
+	SearchGreaterOrEqual SearchModifier = ">"
+)
+
+// modifierOrder is the order in which WAPI expects combined modifiers
+var modifierOrder = map[SearchModifier]int{
+	SearchNegate:          0,
+	SearchCaseInsensitive: 1,
+	SearchRegex:           2,
+	SearchLessOrEqual:     3,
+	SearchGreaterOrEqual:  4,
+}
+
+// Query holds WAPI search arguments sent in the URL of GET requests, in
+// addition to the exact matches on the fields set in the searched object
+//
+//	q := NewQuery().Regex("name", `^web\d+\.example\.com$`).EANotEqual("Site", "lab").MaxResults(100)
+type Query struct {
+	values url.Values
+}
+
+// NewQuery returns an empty Query
+func NewQuery() *Query {
+	return &Query{values: url.Values{}}
+}
+
+// Where adds a condition on field, modified by mods. Extensible attributes
+// are searched with a field name starting with "*".
+func (q *Query) Where(field string, value interface{}, mods ...SearchModifier) *Query {
+	sorted := append([]SearchModifier{}, mods...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return modifierOrder[sorted[i]] < modifierOrder[sorted[j]]
+	})
+
+	key := field
+	for _, m := range sorted {
+		key += string(m)
+	}
+	q.values.Add(key, formatQueryValue(value))
+
+	return q
+}
+
+// Equal matches objects whose field equals value
+func (q *Query) Equal(field string, value interface{}) *Query {
+	return q.Where(field, value)
+}
+
+// NotEqual matches objects whose field differs from value
+func (q *Query) NotEqual(field string, value interface{}) *Query {
+	return q.Where(field, value, SearchNegate)
+}
+
+// EqualFold matches objects whose field equals value ignoring case
+func (q *Query) EqualFold(field string, value interface{}) *Query {
+	return q.Where(field, value, SearchCaseInsensitive)
+}
+
+// Regex matches objects whose field matches the regular expression expr
+func (q *Query) Regex(field string, expr string) *Query {
+	return q.Where(field, expr, SearchRegex)
+}
+
+// LessOrEqual matches objects whose field is lower than or equal to value
+func (q *Query) LessOrEqual(field string, value interface{}) *Query {
+	return q.Where(field, value, SearchLessOrEqual)
+}
+
+// GreaterOrEqual matches objects whose field is greater than or equal to
+// value
+func (q *Query) GreaterOrEqual(field string, value interface{}) *Query {
+	return q.Where(field, value, SearchGreaterOrEqual)
+}
+
+// EA adds a condition on the extensible attribute name, modified by mods
+func (q *Query) EA(name string, value interface{}, mods ...SearchModifier) *Query {
+	return q.Where("*"+name, value, mods...)
+}
+
+// EAEqual matches objects whose extensible attribute name equals value
+func (q *Query) EAEqual(name string, value interface{}) *Query {
+	return q.EA(name, value)
+}
+
+// EANotEqual matches objects whose extensible attribute name differs from
+// value
+func (q *Query) EANotEqual(name string, value interface{}) *Query {
+	return q.EA(name, value, SearchNegate)
+}
+
+// EARegex matches objects whose extensible attribute name matches the
+// regular expression expr
+func (q *Query) EARegex(name string, expr string) *Query {
+	return q.EA(name, expr, SearchRegex)
+}
+
+// MaxResults limits the number of returned objects. A negative n makes WAPI
+// return an error rather than truncating the result when there are more
+// than -n objects.
+func (q *Query) MaxResults(n int) *Query {
+	q.values.Set("_max_results", strconv.Itoa(n))
+	return q
+}
+
+// Values returns the URL query arguments of the Query
+func (q *Query) Values() url.Values {
+	if q == nil {
+		return url.Values{}
+	}
+	return q.values
+}
+
+// String returns the Query encoded as a URL query string
+func (q *Query) String() string {
+	return q.Values().Encode()
+}
+
+func formatQueryValue(value interface{}) string {
+	switch v := value.(type) {
+	case Bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case bool:
+		return formatQueryValue(Bool(v))
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,53 @@
+package ibclient
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestQueryValues(t *testing.T) {
+	q := NewQuery().
+		Regex("name", "^web").
+		EqualFold("comment", "Prod").
+		Where("name", "test", SearchRegex, SearchNegate).
+		GreaterOrEqual("ttl", 300).
+		EARegex("Owner", "^bob").
+		EANotEqual("Site", "lab").
+		Equal("disable", false).
+		MaxResults(-100)
+
+	expected := url.Values{
+		"name~":        {"^web"},
+		"comment:":     {"Prod"},
+		"name!~":       {"test"},
+		"ttl>":         {"300"},
+		"*Owner~":      {"^bob"},
+		"*Site!":       {"lab"},
+		"disable":      {"False"},
+		"_max_results": {"-100"},
+	}
+	if actual := q.Values().Encode(); actual != expected.Encode() {
+		t.Errorf("got %s, expected %s", actual, expected.Encode())
+	}
+}
+
+func TestBuildURLWithQuery(t *testing.T) {
+	wrb := WapiRequestBuilder{HostConfig: HostConfig{Host: "172.22.18.66", Version: "2.5", Port: "443"}}
+	rec := NewRecordA(RecordA{})
+	rec.SetQuery(NewQuery().Regex("name", "^web").EAEqual("Site", "nyc"))
+
+	req, err := wrb.BuildRequest(GET, rec, "", QueryParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q := req.URL.Query()
+	if q.Get("name~") != "^web" || q.Get("*Site") != "nyc" || q.Get("_return_fields") == "" {
+		t.Errorf("unexpected query: %s", req.URL.RawQuery)
+	}
+
+	// the query only applies to GET requests
+	req, _ = wrb.BuildRequest(DELETE, rec, "record:a/ZG5z:web.example.com/default", QueryParams{})
+	if req.URL.RawQuery != "" {
+		t.Errorf("unexpected query for DELETE: %s", req.URL.RawQuery)
+	}
+}
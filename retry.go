@@ -0,0 +1,184 @@
+package ibclient
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is the interface implemented by policies deciding whether a
+// failed WAPI request is sent again by the WapiHTTPRequestor
+type RetryPolicy interface {
+	// ShouldRetry is called after the attempt-th (starting at 1) failed
+	// attempt of req. resp is nil if the request failed with a network
+	// error, otherwise err is nil. It reports whether the request should be
+	// retried and how long to wait before doing so.
+	ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) (retry bool, delay time.Duration)
+}
+
+// BackoffRetryPolicy retries failed requests with an exponential backoff
+type BackoffRetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on each attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) of the delay which is randomized
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP statuses which are retried
+	RetryableStatusCodes []int
+	// RetryNetworkErrors enables retrying connection errors and timeouts
+	RetryNetworkErrors bool
+	// RetryNonIdempotent enables retrying POST requests, which may result
+	// in the object being created twice
+	RetryNonIdempotent bool
+}
+
+// NewBackoffRetryPolicy returns a BackoffRetryPolicy with sensible defaults:
+// 3 attempts, 500ms base delay capped to 10s with 20% jitter, retrying
+// network errors and 429, 502, 503 and 504 responses of idempotent requests.
+func NewBackoffRetryPolicy() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// ShouldRetry implements RetryPolicy
+func (p *BackoffRetryPolicy) ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) (bool, time.Duration) {
+	if attempt >= p.MaxAttempts {
+		return false, 0
+	}
+	if req.Method == RequestType(CREATE).toMethod() && !p.RetryNonIdempotent {
+		return false, 0
+	}
+
+	if err != nil {
+		if !p.RetryNetworkErrors || !isRetryableNetworkError(err) {
+			return false, 0
+		}
+		return true, p.backoff(attempt)
+	}
+
+	if !p.isRetryableStatus(resp.StatusCode) {
+		return false, 0
+	}
+	delay := p.backoff(attempt)
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > delay {
+		delay = retryAfter
+	}
+
+	return true, delay
+}
+
+func (p *BackoffRetryPolicy) isRetryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *BackoffRetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// isRetryableNetworkError reports whether err is a transient transport
+// failure, as opposed to a cancellation or a TLS verification failure
+func isRetryableNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var (
+		unknownAuthorityErr x509.UnknownAuthorityError
+		hostnameErr         x509.HostnameError
+		certInvalidErr      x509.CertificateInvalidError
+	)
+	if errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// ProxySearchMode controls when GET requests are sent to the Grid Master
+// with _proxy_search=GM
+type ProxySearchMode int
+
+const (
+	// ProxySearchFallback re-sends a GET request to the Grid Master when the
+	// member fails with a network or server error, or returns no objects
+	ProxySearchFallback ProxySearchMode = iota
+	// ProxySearchDisabled never proxies requests to the Grid Master
+	ProxySearchDisabled
+	// ProxySearchAlways proxies every GET request to the Grid Master
+	ProxySearchAlways
+)
+
+// shouldProxyFallback reports whether a failed GET request is worth
+// re-sending to the Grid Master. Client errors (4xx) would fail there too.
+func shouldProxyFallback(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var wapiErr *WapiError
+	if errors.As(err, &wapiErr) {
+		return wapiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
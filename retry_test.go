@@ -0,0 +1,112 @@
+package ibclient
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRequestor(policy RetryPolicy) *WapiHTTPRequestor {
+	cfg := NewTransportConfig("false", 5, 1)
+	cfg.RetryPolicy = policy
+	hr := &WapiHTTPRequestor{}
+	hr.Init(cfg)
+	return hr
+}
+
+func TestSendRequestRetriesRetryableStatus(t *testing.T) {
+	var calls int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"name":"x"}` {
+			t.Errorf("unexpected body on attempt %d: %q", calls, body)
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`"networkview/ZG5z:x/false"`))
+	}))
+	defer ts.Close()
+
+	policy := NewBackoffRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	hr := newTestRequestor(policy)
+
+	req, _ := http.NewRequest("PUT", ts.URL+"/wapi/v2.5/networkview/ZG5z:x/false", bytes.NewBufferString(`{"name":"x"}`))
+	res, err := hr.SendRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(res) != `"networkview/ZG5z:x/false"` || calls != 3 {
+		t.Errorf("unexpected result %q after %d calls", res, calls)
+	}
+}
+
+func TestSendRequestDoesNotRetryPost(t *testing.T) {
+	var calls int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	policy := NewBackoffRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	hr := newTestRequestor(policy)
+
+	req, _ := http.NewRequest("POST", ts.URL+"/wapi/v2.5/network", bytes.NewBufferString(`{}`))
+	_, err := hr.SendRequest(req)
+	var wapiErr *WapiError
+	if !errors.As(err, &wapiErr) || wapiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 WapiError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestBackoffRetryPolicy(t *testing.T) {
+	policy := &BackoffRetryPolicy{
+		MaxAttempts:          4,
+		BaseDelay:            time.Second,
+		MaxDelay:             3 * time.Second,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+	get, _ := http.NewRequest("GET", "https://grid/wapi/v2.5/network", nil)
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 3 * time.Second} {
+		retry, delay := policy.ShouldRetry(attempt, get, unavailable, nil)
+		if !retry || delay != expected {
+			t.Errorf("attempt %d: got (%v, %v), expected (true, %v)", attempt, retry, delay, expected)
+		}
+	}
+	if retry, _ := policy.ShouldRetry(4, get, unavailable, nil); retry {
+		t.Error("expected no retry once MaxAttempts is reached")
+	}
+
+	badRequest := &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}
+	if retry, _ := policy.ShouldRetry(1, get, badRequest, nil); retry {
+		t.Error("expected no retry for a 400 response")
+	}
+
+	unavailable.Header.Set("Retry-After", "7")
+	if _, delay := policy.ShouldRetry(1, get, unavailable, nil); delay != 7*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %v", delay)
+	}
+}
+
+func TestShouldProxyFallback(t *testing.T) {
+	if shouldProxyFallback(NewWapiError("GET", "network", http.StatusBadRequest, nil)) {
+		t.Error("client errors should not be proxied to the Grid Master")
+	}
+	if !shouldProxyFallback(NewWapiError("GET", "network", http.StatusBadGateway, nil)) {
+		t.Error("server errors should be proxied to the Grid Master")
+	}
+}
@@ -0,0 +1,157 @@
+package ibclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// AuthMode selects how a Connector authenticates its WAPI requests
+type AuthMode int
+
+const (
+	// AuthBasic sends the credentials with every request
+	AuthBasic AuthMode = iota
+	// AuthSession sends the credentials once to log in and then reuses the
+	// ibapauth session cookie set by the Grid, logging in again when the
+	// session expires. It requires an HTTPRequestor keeping cookies, such
+	// as the WapiHTTPRequestor.
+	AuthSession
+)
+
+// CookieClearer is implemented by HTTPRequestors keeping session cookies
+// which can be discarded, e.g. after a logout
+type CookieClearer interface {
+	ClearCookies()
+}
+
+// ClearCookies discards the session cookies set by the Grid
+func (whr *WapiHTTPRequestor) ClearCookies() {
+	if whr.jar != nil {
+		whr.jar.clear()
+	}
+}
+
+// sessionJar is a cookie jar which can be emptied while requests using it
+// are in flight
+type sessionJar struct {
+	mu  sync.RWMutex
+	jar *cookiejar.Jar
+}
+
+func newSessionJar() (*sessionJar, error) {
+	jar, err := newCookieJar()
+	if err != nil {
+		return nil, err
+	}
+	return &sessionJar{jar: jar}, nil
+}
+
+func newCookieJar() (*cookiejar.Jar, error) {
+	// All users of cookiejar should import "golang.org/x/net/publicsuffix"
+	return cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+}
+
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+func (j *sessionJar) clear() {
+	jar, err := newCookieJar()
+	if err != nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar = jar
+}
+
+func (c *Connector) hasSession() bool {
+	return atomic.LoadInt32(&c.session) == 1
+}
+
+func (c *Connector) startSession() {
+	atomic.StoreInt32(&c.session, 1)
+}
+
+// endSession forgets the current session, the next request logs in again
+func (c *Connector) endSession() {
+	atomic.StoreInt32(&c.session, 0)
+	if cc, ok := c.Requestor.(CookieClearer); ok {
+		cc.ClearCookies()
+	}
+}
+
+// sendAuthenticated sends a request with the credentials or the session
+// cookie, as selected by the AuthMode of the HostConfig
+func (c *Connector) sendAuthenticated(ctx context.Context, t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
+	if c.HostConfig.AuthMode != AuthSession {
+		res, err = c.sendRequest(ctx, t, obj, ref, queryParams)
+		if errors.Is(err, ErrUnauthorized) && c.invalidateCredentials() {
+			res, err = c.sendRequest(ctx, t, obj, ref, queryParams)
+		}
+		return
+	}
+
+	queryParams.authenticate = !c.hasSession()
+	res, err = c.sendRequest(ctx, t, obj, ref, queryParams)
+	if errors.Is(err, ErrUnauthorized) {
+		retry := c.invalidateCredentials()
+		if !queryParams.authenticate {
+			loggerOrNop(c.Logger).Info("WAPI session expired, logging in again", "host", c.HostConfig.Host)
+			c.endSession()
+			retry = true
+		}
+		if retry {
+			queryParams.authenticate = true
+			res, err = c.sendRequest(ctx, t, obj, ref, queryParams)
+		}
+	}
+	if err == nil && queryParams.authenticate {
+		c.startSession()
+	}
+
+	return
+}
+
+// invalidateCredentials drops the credentials cached by the
+// CredentialProvider, such as CachedCredentials, as they were rejected and
+// may have been rotated. It reports whether the provider caches them.
+func (c *Connector) invalidateCredentials() bool {
+	inv, ok := c.HostConfig.Credentials.(interface{ Invalidate() })
+	if !ok {
+		return false
+	}
+	loggerOrNop(c.Logger).Info("WAPI credentials rejected, fetching them again", "host", c.HostConfig.Host)
+	inv.Invalidate()
+	return true
+}
+
+// Login authenticates with the Grid. With AuthSession, the following
+// requests reuse the session cookie until Logout is called; otherwise it
+// only validates the credentials.
+func (c *Connector) Login() error {
+	return c.LoginWithContext(context.Background())
+}
+
+// LoginWithContext is like Login but the request is bound to ctx.
+func (c *Connector) LoginWithContext(ctx context.Context) error {
+	c.endSession()
+
+	var res []UserProfile
+	return c.GetObjectWithContext(ctx, NewUserProfile(UserProfile{}), "", &res)
+}
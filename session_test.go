@@ -0,0 +1,120 @@
+package ibclient
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// sessionGrid emulates the WAPI session handling: Basic Auth logs in and
+// sets an ibapauth cookie, which authenticates the following requests
+type sessionGrid struct {
+	mu         sync.Mutex
+	session    string
+	logins     int
+	logouts    int
+	lastCookie string
+}
+
+func (g *sessionGrid) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.lastCookie = ""
+	if c, err := r.Cookie("ibapauth"); err == nil {
+		g.lastCookie = c.Value
+	}
+
+	switch user, pass, ok := r.BasicAuth(); {
+	case ok && user == "admin" && pass == "secret":
+		g.logins++
+		g.session = "session" + strconv.Itoa(g.logins)
+		http.SetCookie(w, &http.Cookie{Name: "ibapauth", Value: g.session})
+	case g.lastCookie == "" || g.lastCookie != g.session:
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"Error": "AdmConProtoError: Authorization required", "code": "Client.Ibap.Proto", "text": "Authorization required"}`))
+		return
+	}
+
+	if r.URL.Path == "/wapi/v2.5/logout" {
+		g.logouts++
+		g.session = ""
+	}
+	_, _ = w.Write([]byte(`[{"_ref": "userprofile/ZG5z:admin", "name": "admin"}]`))
+}
+
+func (g *sessionGrid) expire() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.session = ""
+}
+
+func newSessionConnector(t *testing.T, grid *sessionGrid) *Connector {
+	conn := newTestConnector(t, grid.ServeHTTP)
+	conn.HostConfig.AuthMode = AuthSession
+	conn.RequestBuilder.Init(conn.HostConfig)
+	return conn
+}
+
+func TestSessionAuthReusesCookie(t *testing.T) {
+	grid := &sessionGrid{}
+	conn := newSessionConnector(t, grid)
+
+	for i := 0; i < 3; i++ {
+		var res []UserProfile
+		if err := conn.GetObject(NewUserProfile(UserProfile{}), "", &res); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if grid.logins != 1 {
+		t.Errorf("expected a single login, got %d", grid.logins)
+	}
+}
+
+func TestSessionAuthLogsInAgainWhenExpired(t *testing.T) {
+	grid := &sessionGrid{}
+	conn := newSessionConnector(t, grid)
+
+	if err := conn.Login(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	grid.expire()
+
+	var res []UserProfile
+	if err := conn.GetObject(NewUserProfile(UserProfile{}), "", &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if grid.logins != 2 {
+		t.Errorf("expected two logins, got %d", grid.logins)
+	}
+}
+
+func TestSessionAuthLogout(t *testing.T) {
+	grid := &sessionGrid{}
+	conn := newSessionConnector(t, grid)
+
+	if err := conn.Login(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := conn.Logout(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if grid.logouts != 1 || grid.lastCookie != "session1" {
+		t.Errorf("expected the logout to send the session cookie, got %d logouts with %q", grid.logouts, grid.lastCookie)
+	}
+
+	// the cookie is gone, the next request logs in again
+	var res []UserProfile
+	if err := conn.GetObject(NewUserProfile(UserProfile{}), "", &res); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if grid.logins != 2 || grid.lastCookie != "" {
+		t.Errorf("expected a new login without cookie, got %d logins with %q", grid.logins, grid.lastCookie)
+	}
+
+	// a second logout has no session to invalidate
+	if err := conn.Logout(); err != nil || conn.Logout() != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
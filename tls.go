@@ -0,0 +1,195 @@
+package ibclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// TLSConfig holds the TLS options of a TransportConfig. The zero value
+// verifies the Grid certificate against the system CAs.
+type TLSConfig struct {
+	// InsecureSkipVerify disables the verification of the Grid certificate.
+	// Certificate pins are still checked, against the Grid certificate
+	// itself as the chain isn't verified.
+	InsecureSkipVerify bool
+	// CAFiles are files of PEM encoded CA certificates trusted to sign the
+	// Grid certificate, instead of the system CAs
+	CAFiles []string
+	// CAPEMs are PEM encoded CA certificates, in addition to CAFiles
+	CAPEMs [][]byte
+	// UseSystemCAs trusts the system CAs in addition to CAFiles and CAPEMs
+	UseSystemCAs bool
+	// MinVersion is the minimum TLS version, e.g. tls.VersionTLS12. The
+	// default of the crypto/tls package is used if 0.
+	MinVersion uint16
+	// CipherSuites restricts the TLS 1.0-1.2 cipher suites, the defaults of
+	// the crypto/tls package are used if empty
+	CipherSuites []uint16
+	// ServerName overrides the name sent with SNI and verified against the
+	// Grid certificate, e.g. when connecting by IP address
+	ServerName string
+	// PinnedSPKIHashes are base64 encoded SHA-256 hashes of the subject
+	// public key info of certificates, as used by HPKP ("pin-sha256"). If
+	// set, the connection fails unless a certificate of the verified chain
+	// matches, or the Grid certificate with InsecureSkipVerify.
+	PinnedSPKIHashes []string
+}
+
+// NewTransportConfigWithTLS returns a TransportConfig using the given TLS
+// options. Unlike NewTransportConfig it reports invalid options instead of
+// silently ignoring them.
+func NewTransportConfigWithTLS(tlsCfg TLSConfig, httpRequestTimeout int, httpPoolConnections int) (cfg TransportConfig, err error) {
+	cfg.HTTPPoolConnections = httpPoolConnections
+	cfg.HTTPRequestTimeout = time.Duration(httpRequestTimeout)
+	cfg.RetryPolicy = NewBackoffRetryPolicy()
+	cfg.SslVerify = !tlsCfg.InsecureSkipVerify
+
+	// until the options are validated, nothing can be verified
+	cfg.certPool = x509.NewCertPool()
+
+	pool, err := tlsCfg.certPool()
+	if err != nil {
+		return
+	}
+	if err = validateTLSVersion(tlsCfg.MinVersion); err != nil {
+		return
+	}
+	if err = validateCipherSuites(tlsCfg.CipherSuites); err != nil {
+		return
+	}
+	pins, err := parseSPKIPins(tlsCfg.PinnedSPKIHashes)
+	if err != nil {
+		return
+	}
+
+	cfg.certPool = pool
+	cfg.minVersion = tlsCfg.MinVersion
+	cfg.cipherSuites = tlsCfg.CipherSuites
+	cfg.serverName = tlsCfg.ServerName
+	cfg.spkiPins = pins
+	return
+}
+
+// certPool returns the pool of trusted CAs, nil for the system pool
+func (tc TLSConfig) certPool() (*x509.CertPool, error) {
+	if len(tc.CAFiles) == 0 && len(tc.CAPEMs) == 0 {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+	if tc.UseSystemCAs {
+		sysPool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the system CAs: %w", err)
+		}
+		pool = sysPool
+	}
+
+	for _, f := range tc.CAFiles {
+		pemCerts, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load certificate file '%s': %w", f, err)
+		}
+		if !pool.AppendCertsFromPEM(pemCerts) {
+			return nil, fmt.Errorf("cannot append certificate from file '%s'", f)
+		}
+	}
+	for i, pemCerts := range tc.CAPEMs {
+		if !pool.AppendCertsFromPEM(pemCerts) {
+			return nil, fmt.Errorf("cannot append certificate from CA PEM #%d", i)
+		}
+	}
+
+	return pool, nil
+}
+
+func validateTLSVersion(v uint16) error {
+	switch v {
+	case 0, tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+		return nil
+	}
+	return fmt.Errorf("unknown TLS version 0x%04x", v)
+}
+
+func validateCipherSuites(ids []uint16) error {
+	known := make(map[uint16]bool)
+	for _, cs := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		known[cs.ID] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return fmt.Errorf("unknown cipher suite 0x%04x", id)
+		}
+	}
+	return nil
+}
+
+func parseSPKIPins(hashes []string) ([][]byte, error) {
+	var pins [][]byte
+	for _, h := range hashes {
+		pin, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(h, "sha256/"))
+		if err != nil || len(pin) != sha256.Size {
+			return nil, fmt.Errorf("invalid SPKI SHA-256 pin '%s'", h)
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// errNoPinMatch is returned when no certificate matches the SPKI pins
+var errNoPinMatch = errors.New("no Grid certificate matches the pinned SPKI hashes")
+
+// verifyPins returns a tls.Config VerifyPeerCertificate function checking
+// that a certificate sent by the Grid matches one of pins
+func verifyPins(pins [][]byte) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		var certs []*x509.Certificate
+		for _, chain := range verifiedChains {
+			certs = append(certs, chain...)
+		}
+		// verifiedChains is empty if verification is disabled, only the leaf
+		// is checked then as the rest of the chain isn't bound to it
+		if len(certs) == 0 && len(rawCerts) > 0 {
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+			certs = append(certs, cert)
+		}
+
+		for _, cert := range certs {
+			sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			for _, pin := range pins {
+				if bytes.Equal(sum[:], pin) {
+					return nil
+				}
+			}
+		}
+		return errNoPinMatch
+	}
+}
+
+// tlsClientConfig returns the crypto/tls configuration of cfg
+func (cfg TransportConfig) tlsClientConfig() *tls.Config {
+	tc := &tls.Config{
+		InsecureSkipVerify: !cfg.SslVerify,
+		RootCAs:            cfg.certPool,
+		Certificates:       cfg.ClientCertificates,
+		MinVersion:         cfg.minVersion,
+		CipherSuites:       cfg.cipherSuites,
+		ServerName:         cfg.serverName,
+		Renegotiation:      tls.RenegotiateOnceAsClient,
+	}
+	if len(cfg.spkiPins) > 0 {
+		tc.VerifyPeerCertificate = verifyPins(cfg.spkiPins)
+	}
+	return tc
+}
@@ -0,0 +1,128 @@
+package ibclient
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewTransportConfigFailsClosed(t *testing.T) {
+	cfg := NewTransportConfig("/nonexistent/ca.pem", 5, 1)
+	if !cfg.SslVerify {
+		t.Error("an unreadable CA file must not disable verification")
+	}
+
+	hr := &WapiHTTPRequestor{}
+	hr.Init(cfg)
+	req, _ := http.NewRequest("GET", "https://127.0.0.1:1/wapi/v2.5/grid", nil)
+	_, err := hr.SendRequest(req)
+	if err == nil || !strings.Contains(err.Error(), "/nonexistent/ca.pem") {
+		t.Errorf("expected the CA file error, got %v", err)
+	}
+}
+
+func TestNewTransportConfigWithTLSErrors(t *testing.T) {
+	tests := map[string]TLSConfig{
+		"missing CA file": {CAFiles: []string{"/nonexistent/ca.pem"}},
+		"invalid CA PEM":  {CAPEMs: [][]byte{[]byte("not a certificate")}},
+		"invalid version": {MinVersion: 0x0200},
+		"unknown cipher":  {CipherSuites: []uint16{0xffff}},
+		"invalid pin":     {PinnedSPKIHashes: []string{"sha256/abc"}},
+	}
+	for name, tlsCfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := NewTransportConfigWithTLS(tlsCfg, 5, 1)
+			if err == nil {
+				t.Error("expected an error")
+			}
+			if !cfg.SslVerify {
+				t.Error("verification must stay enabled")
+			}
+		})
+	}
+}
+
+func sendTLSTestRequest(t *testing.T, ts *httptest.Server, tlsCfg TLSConfig) error {
+	cfg, err := NewTransportConfigWithTLS(tlsCfg, 5, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg.RetryPolicy = nil
+	hr := &WapiHTTPRequestor{}
+	hr.Init(cfg)
+
+	req, _ := http.NewRequest("GET", ts.URL+"/wapi/v2.5/grid", nil)
+	_, err = hr.SendRequest(req)
+	return err
+}
+
+func TestTLSConfigVerification(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	sum := sha256.Sum256(ts.Certificate().RawSubjectPublicKeyInfo)
+	pin := base64.StdEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		tlsCfg  TLSConfig
+		success bool
+	}{
+		{"untrusted CA", TLSConfig{}, false},
+		{"trusted CA", TLSConfig{CAPEMs: [][]byte{caPEM}, MinVersion: tls.VersionTLS12}, true},
+		{"trusted CA merged with system CAs", TLSConfig{CAPEMs: [][]byte{caPEM}, UseSystemCAs: true}, true},
+		{"SNI override", TLSConfig{CAPEMs: [][]byte{caPEM}, ServerName: "example.com"}, true},
+		{"wrong server name", TLSConfig{CAPEMs: [][]byte{caPEM}, ServerName: "grid.example.org"}, false},
+		{"matching pin", TLSConfig{InsecureSkipVerify: true, PinnedSPKIHashes: []string{"sha256/" + pin}}, true},
+		{"mismatching pin", TLSConfig{CAPEMs: [][]byte{caPEM}, PinnedSPKIHashes: []string{base64.StdEncoding.EncodeToString(make([]byte, 32))}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sendTLSTestRequest(t, ts, tt.tlsCfg)
+			if tt.success && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.success && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestTLSPinIgnoresUnverifiedChain(t *testing.T) {
+	leafPEM, leafKeyPEM := newTestKeyPair(t)
+	leaf, err := tls.X509KeyPair(leafPEM, leafKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinnedPEM, _ := newTestKeyPair(t)
+	pinnedBlock, _ := pem.Decode(pinnedPEM)
+	pinned, err := x509.ParseCertificate(pinnedBlock.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(pinned.RawSubjectPublicKeyInfo)
+	pin := base64.StdEncoding.EncodeToString(sum[:])
+
+	// an unrelated leaf followed by the public pinned certificate
+	leaf.Certificate = append(leaf.Certificate, pinned.Raw)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	ts.TLS = &tls.Config{Certificates: []tls.Certificate{leaf}}
+	ts.StartTLS()
+	defer ts.Close()
+
+	if err := sendTLSTestRequest(t, ts, TLSConfig{InsecureSkipVerify: true, PinnedSPKIHashes: []string{pin}}); err == nil {
+		t.Error("expected an error as the Grid certificate doesn't match the pin")
+	}
+}